@@ -0,0 +1,204 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run ./cmd/integragen -o commands_gen.go commands.json
+
+package integra
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidParameter is returned when a message's parameter is not
+// accepted by its command according to the command catalog.
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// A ZoneID identifies one of the zones of an Integra device.
+type ZoneID int
+
+const (
+	MainZone ZoneID = iota
+	Zone2
+	Zone3
+	Zone4
+)
+
+func (z ZoneID) String() string {
+	switch z {
+	case MainZone:
+		return "Main"
+	case Zone2, Zone3, Zone4:
+		return fmt.Sprintf("Zone %d", int(z)+1)
+	}
+	return fmt.Sprintf("ZoneID(%d)", int(z))
+}
+
+// A Command describes an ISCP command in the catalog.
+type Command struct {
+	// Code is the three character ISCP command, e.g., "MVL".
+	Code string
+	// Name is a human-readable name, e.g., "Master Volume".
+	Name string
+	// Zone is the zone the command applies to. System-wide
+	// commands belong to MainZone.
+	Zone ZoneID
+	// Range is the numeric parameter range accepted by the
+	// command, or nil if it doesn't take a numeric parameter.
+	Range *Range
+	// Values are the enumerated parameters accepted by the
+	// command.
+	Values []Value
+	// Pattern is a regular expression matching any other
+	// parameters accepted by the command, e.g., tone settings.
+	Pattern string
+	// Query reports whether the command accepts QSTN.
+	Query bool
+	// Up and Down report whether the command accepts UP and DOWN.
+	Up, Down bool
+
+	pattern *regexp.Regexp
+}
+
+// A Range is a range of numeric parameter values encoded as
+// fixed-width hex, e.g., "00" through "64" for volume.
+type Range struct {
+	Min, Max int
+	// Width is the number of hex digits in the parameter.
+	Width int
+}
+
+// A Value is an enumerated parameter value.
+type Value struct {
+	// Param is the parameter as sent in the message, e.g., "01".
+	Param string
+	// Name is a human-readable name, e.g., "On".
+	Name string
+}
+
+// commandIndex maps command codes to catalog entries.
+var commandIndex = make(map[string]*Command)
+
+func init() {
+	for i := range catalog {
+		c := &catalog[i]
+		if c.Pattern != "" {
+			c.pattern = regexp.MustCompile("^(?:" + c.Pattern + ")$")
+		}
+		commandIndex[c.Code] = c
+	}
+}
+
+// LookupCommand returns the catalog entry for the given command code
+// (e.g., "MVL") and reports whether it was found.
+func LookupCommand(code string) (*Command, bool) {
+	c, ok := commandIndex[code]
+	return c, ok
+}
+
+// Commands returns the catalog of known ISCP commands ordered by
+// code.
+func Commands() []*Command {
+	commands := make([]*Command, 0, len(catalog))
+	for i := range catalog {
+		commands = append(commands, &catalog[i])
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Code < commands[j].Code
+	})
+	return commands
+}
+
+// ValidateMessage checks the message's parameter against the catalog
+// entry for its command and returns an error wrapping
+// ErrInvalidParameter if the parameter isn't accepted. Messages with
+// commands missing from the catalog are not checked.
+func ValidateMessage(m *Message) error {
+	c, ok := LookupCommand(m.Command)
+	if !ok {
+		return nil
+	}
+	return c.Validate(m.Parameter)
+}
+
+// Validate returns an error wrapping ErrInvalidParameter unless the
+// command accepts param.
+func (c *Command) Validate(param string) error {
+	if c.accepts(param) {
+		return nil
+	}
+	return fmt.Errorf("%v%v: %w %q for %v", c.Code, param, ErrInvalidParameter, param, c.Name)
+}
+
+func (c *Command) accepts(param string) bool {
+	switch {
+	case param == "QSTN" && c.Query:
+		return true
+	case param == "UP" && c.Up:
+		return true
+	case param == "DOWN" && c.Down:
+		return true
+	case c.Range != nil && c.Range.contains(param):
+		return true
+	case c.pattern != nil && c.pattern.MatchString(param):
+		return true
+	}
+	_, ok := c.Value(param)
+	return ok
+}
+
+// Value returns the enumerated value with the given parameter and
+// reports whether it was found.
+func (c *Command) Value(param string) (Value, bool) {
+	for _, v := range c.Values {
+		if v.Param == param {
+			return v, true
+		}
+	}
+	return Value{}, false
+}
+
+func (r *Range) contains(param string) bool {
+	_, err := r.Decode(param)
+	return err == nil
+}
+
+// Encode returns the parameter encoding the value v. The error wraps
+// ErrInvalidParameter if v is out of range.
+func (r *Range) Encode(v int) (string, error) {
+	if v < r.Min || v > r.Max {
+		return "", fmt.Errorf("%w: %v out of range [%v, %v]", ErrInvalidParameter, v, r.Min, r.Max)
+	}
+	return fmt.Sprintf("%0*X", r.Width, v), nil
+}
+
+// Decode returns the value encoded by param. The error wraps
+// ErrInvalidParameter if param is malformed or out of range.
+func (r *Range) Decode(param string) (int, error) {
+	if len(param) != r.Width {
+		return 0, fmt.Errorf("%w: %q is not %v hex digits", ErrInvalidParameter, param, r.Width)
+	}
+	v, err := strconv.ParseUint(strings.ToUpper(param), 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not hex", ErrInvalidParameter, param)
+	}
+	if int(v) < r.Min || int(v) > r.Max {
+		return 0, fmt.Errorf("%w: %v out of range [%v, %v]", ErrInvalidParameter, v, r.Min, r.Max)
+	}
+	return int(v), nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		valid   bool
+	}{
+		{"PWR01", true},
+		{"PWR00", true},
+		{"PWRQSTN", true},
+		{"PWR02", false},
+		{"PWRUP", false},
+		{"MVL00", true},
+		{"MVL2A", true},
+		{"MVL2a", true},
+		{"MVL64", true},
+		{"MVL65", false},
+		{"MVL999", false},
+		{"MVLUP", true},
+		{"MVLDOWN1", true},
+		{"MVLQSTN", true},
+		{"AMTTG", true},
+		{"SLI2B", true},
+		{"SLI7F", false},
+		{"SLZ7F", true},
+		{"TFRB+2", true},
+		{"TFRB+2T-A", true},
+		{"TFRB+B", false},
+		{"TUN08930", true},
+		{"TUN8930", false},
+		{"NTCPLAY", true},
+		{"NTCQSTN", false},
+		{"ZVL1E", true},
+		{"LMDMOVIE", true},
+		{"LMD80", true},
+		// Commands missing from the catalog aren't checked.
+		{"XYZ123", true},
+	}
+	for _, tt := range tests {
+		m, err := NewMessage([]byte(tt.message))
+		if err != nil {
+			t.Fatalf("NewMessage(%v) failed: %v", tt.message, err)
+		}
+		err = ValidateMessage(m)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateMessage(%v) returned %v, expected nil", tt.message, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("ValidateMessage(%v) returned %v, expected %v", tt.message, err, ErrInvalidParameter)
+		}
+	}
+}
+
+func TestLookupCommand(t *testing.T) {
+	c, ok := LookupCommand("ZVL")
+	if !ok {
+		t.Fatal("LookupCommand(ZVL) failed")
+	}
+	if c.Zone != Zone2 || c.Name != "Zone 2 Volume" || !c.Up || !c.Down || !c.Query {
+		t.Errorf("LookupCommand(ZVL) returned %+v", c)
+	}
+	if _, ok := LookupCommand("XYZ"); ok {
+		t.Error("LookupCommand(XYZ) succeeded, expected failure")
+	}
+	commands := Commands()
+	for i := 1; i < len(commands); i++ {
+		if commands[i-1].Code >= commands[i].Code {
+			t.Errorf("Commands not ordered or duplicated: %v, %v", commands[i-1].Code, commands[i].Code)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	r := Range{0, 0x64, 2}
+	if s, err := r.Encode(42); err != nil || s != "2A" {
+		t.Errorf("Encode(42) returned %q, %v, expected 2A", s, err)
+	}
+	if _, err := r.Encode(101); err == nil {
+		t.Error("Encode(101) succeeded, expected error")
+	}
+	if v, err := r.Decode("2A"); err != nil || v != 42 {
+		t.Errorf("Decode(2A) returned %v, %v, expected 42", v, err)
+	}
+	for _, param := range []string{"", "2", "65", "ZZ", "+1", "002"} {
+		if _, err := r.Decode(param); err == nil {
+			t.Errorf("Decode(%q) succeeded, expected error", param)
+		}
+	}
+}
+
+func TestSendInvalid(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"MVL", "999"}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Send(MVL999) returned %v, expected %v", err, ErrInvalidParameter)
+	}
+	if err := client.Send(&Message{"MVL", "2A"}); err != nil {
+		t.Error("Send(MVL2A) failed:", err)
+	}
+	transport.expectWritten(t, "MVL2A")
+}
+
+func TestSkipValidation(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), SkipValidation())
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"MVL", "999"}); err != nil {
+		t.Error("Send(MVL999) failed:", err)
+	}
+	transport.expectWritten(t, "MVL999")
+}
+
+func TestGeneratedAPI(t *testing.T) {
+	m, err := MasterVolumeMessage(42)
+	if err != nil || m.String() != "MVL2A" {
+		t.Errorf("MasterVolumeMessage(42) returned %v, %v, expected MVL2A", m, err)
+	}
+	if _, err := MasterVolumeMessage(101); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("MasterVolumeMessage(101) returned %v, expected %v", err, ErrInvalidParameter)
+	}
+	if v, err := DecodeMasterVolume("2A"); err != nil || v != 42 {
+		t.Errorf("DecodeMasterVolume(2A) returned %v, %v, expected 42", v, err)
+	}
+	if m := InputSelectorMessage(InputDVDBD); m.String() != "SLI10" {
+		t.Errorf("InputSelectorMessage(InputDVDBD) returned %v, expected SLI10", m)
+	}
+	if s := InputDVDBD.String(); s != "DVD/BD" {
+		t.Errorf("InputDVDBD.String() returned %q, expected DVD/BD", s)
+	}
+	if v, err := ParseInput("2B"); err != nil || v != InputNetwork {
+		t.Errorf("ParseInput(2B) returned %v, %v, expected %v", v, err, InputNetwork)
+	}
+	if _, err := ParseInput("ZZ"); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("ParseInput(ZZ) returned %v, expected %v", err, ErrInvalidParameter)
+	}
+	if c, ok := LookupCommand(CmdZone2Power); !ok || c.Zone != Zone2 {
+		t.Errorf("LookupCommand(CmdZone2Power) returned %+v, %v", c, ok)
+	}
+}
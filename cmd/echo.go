@@ -14,11 +14,15 @@
 
 // Mock Integra device server that echoes received messages back to
 // client for testing. A hard-coded message ("PWR01") is sent to
-// client when this server receives a HUP signal.
+// client when this server receives a HUP signal. The server also
+// answers eISCP discovery queries (ECNQSTN) on UDP port 60128.
 package main
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
@@ -32,13 +36,16 @@ func printBytes(b []byte) {
 		fmt.Printf("%#02x", v)
 		if i%4 == 3 {
 			fmt.Printf("\n")
-			if i == 15 || i == 31 {
+			if i == 15 {
 				fmt.Printf("\n")
 			}
 		} else {
 			fmt.Print(" ")
 		}
 	}
+	if len(b)%4 != 0 {
+		fmt.Printf("\n")
+	}
 }
 
 func handleClient(conn net.Conn) {
@@ -47,7 +54,7 @@ func handleClient(conn net.Conn) {
 		log.Println("Closing connection")
 		_ = conn.Close()
 	}()
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, syscall.SIGHUP)
 	go func() {
 		for {
@@ -60,9 +67,7 @@ func handleClient(conn net.Conn) {
 				0x01, 0x00, 0x00, 0x00,
 
 				0x21, 0x31, 0x50, 0x57,
-				0x52, 0x30, 0x31, 0x1a,
-				0x00, 0x00, 0x00, 0x00,
-				0x00, 0x00, 0x00, 0x00}
+				0x52, 0x30, 0x31, 0x1a}
 
 			n, err := conn.Write(buffer)
 			if err != nil {
@@ -75,22 +80,31 @@ func handleClient(conn net.Conn) {
 	}()
 
 	for {
-		buffer := make([]byte, 32)
-		n, err := conn.Read(buffer)
+		// Read the 16 byte header first; the data size is
+		// stored big-endian in bytes 8-11.
+		header := make([]byte, 16)
+		_, err := io.ReadFull(conn, header)
+		if err != nil {
+			log.Println("Error reading:", err)
+			return
+		}
+		buffer := make([]byte, 16+binary.BigEndian.Uint32(header[8:]))
+		copy(buffer, header)
+		_, err = io.ReadFull(conn, buffer[16:])
 		if err != nil {
 			log.Println("Error reading:", err)
 			return
 		}
-		log.Printf("Read %d bytes:\n", n)
+		log.Printf("Read %d bytes:\n", len(buffer))
 		printBytes(buffer)
 
 		time.Sleep(40 * time.Millisecond)
 
 		// Replace client Tx end of packet marker with client
 		// Rx marker.
-		buffer[16+buffer[11]-1] = 0x1a
+		buffer[len(buffer)-1] = 0x1a
 
-		n, err = conn.Write(buffer)
+		n, err := conn.Write(buffer)
 		if err != nil {
 			log.Println("Error writing:", err)
 			return
@@ -100,8 +114,47 @@ func handleClient(conn net.Conn) {
 	}
 }
 
+// discoveryReply returns an eISCP packet containing the ECN message
+// sent in reply to a discovery query.
+func discoveryReply() []byte {
+	data := "!1ECNECHO/60128/XX/000000000000\x19\r\n"
+	buffer := []byte{
+		0x49, 0x53, 0x43, 0x50,
+		0x00, 0x00, 0x00, 0x10,
+		0x00, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00}
+	binary.BigEndian.PutUint32(buffer[8:], uint32(len(data)))
+	return append(buffer, data...)
+}
+
+// serveDiscovery answers discovery queries until an error occurs.
+func serveDiscovery(conn *net.UDPConn) {
+	buffer := make([]byte, 64)
+	for {
+		n, addr, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			log.Println("Error reading discovery query:", err)
+			return
+		}
+		if !bytes.Contains(buffer[:n], []byte("ECNQSTN")) {
+			continue
+		}
+		log.Println("Answering discovery query from", addr)
+		if _, err := conn.WriteToUDP(discoveryReply(), addr); err != nil {
+			log.Println("Error writing discovery reply:", err)
+		}
+	}
+}
+
 func main() {
 	log.SetOutput(os.Stdout)
+	udp, err := net.ListenUDP("udp4", &net.UDPAddr{Port: 60128})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() { _ = udp.Close() }()
+	go serveDiscovery(udp)
+
 	l, err := net.Listen("tcp", ":60128")
 	if err != nil {
 		log.Fatal(err)
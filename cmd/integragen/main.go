@@ -0,0 +1,540 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Integragen generates the command catalog and typed command API of
+// package integra from an ISCP command specification file.
+//
+// Usage:
+//
+//   integragen [-o output] [-package name] spec.json
+//
+// The specification is a JSON object with two lists. "enums" describes
+// parameter enumerations shared by several commands; each becomes a
+// string type with a constant per value. "commands" describes the ISCP
+// commands:
+//
+//   {
+//     "code": "MVL",               // three character ISCP command
+//     "ident": "MasterVolume",     // Go identifier used in the API
+//     "name": "Master Volume",     // human-readable name
+//     "zone": "main",              // main (default), zone2, zone3 or zone4
+//     "enum": "Power",             // shared enumeration of parameters
+//     "exclude": ["7F"],           // enum values not accepted
+//     "values": [{"param": "00", "name": "Off"}],  // command's own enumeration
+//     "extra": [{"param": "UP1", "name": "Up 1 dB"}], // untyped parameters
+//     "range": {"min": 0, "max": 100, "width": 2},  // hex-encoded range
+//     "pattern": "[0-9]{5}",       // regexp matching other parameters
+//     "syntax": "frequencies",     // description of pattern for docs
+//     "query": true,               // accepts QSTN
+//     "up": true, "down": true     // accepts UP and DOWN
+//   }
+//
+// Adding a command is an edit to the specification followed by
+// go generate in the integra package directory.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var (
+	output      = flag.String("o", "", "output file (default standard output)")
+	packageName = flag.String("package", "integra", "package name of the generated file")
+)
+
+// A spec is an ISCP command specification.
+type spec struct {
+	Enums    []*enum    `json:"enums"`
+	Commands []*command `json:"commands"`
+}
+
+// An enum is a named enumeration of parameter values.
+type enum struct {
+	Name   string   `json:"name"`
+	Doc    string   `json:"doc"`
+	Values []*value `json:"values"`
+}
+
+// A value is an enumerated parameter value.
+type value struct {
+	Param string `json:"param"`
+	Name  string `json:"name"`
+	Ident string `json:"ident"`
+}
+
+// A command is an ISCP command.
+type command struct {
+	Code    string   `json:"code"`
+	Ident   string   `json:"ident"`
+	Name    string   `json:"name"`
+	Zone    string   `json:"zone"`
+	Enum    string   `json:"enum"`
+	Exclude []string `json:"exclude"`
+	Values  []*value `json:"values"`
+	Extra   []*value `json:"extra"`
+	Range   *struct {
+		Min   int `json:"min"`
+		Max   int `json:"max"`
+		Width int `json:"width"`
+	} `json:"range"`
+	Pattern string `json:"pattern"`
+	Syntax  string `json:"syntax"`
+	Query   bool   `json:"query"`
+	Up      bool   `json:"up"`
+	Down    bool   `json:"down"`
+
+	// enum is the enumeration of the command's parameters,
+	// either shared or the command's own.
+	enum *enum
+}
+
+var zones = map[string]string{
+	"":      "MainZone",
+	"main":  "MainZone",
+	"zone2": "Zone2",
+	"zone3": "Zone3",
+	"zone4": "Zone4",
+}
+
+var (
+	codePattern  = regexp.MustCompile(`^[A-Z0-9]{3}$`)
+	identPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+func readSpec(path string) (*spec, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s spec
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	if err := s.check(); err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return &s, nil
+}
+
+// check validates the specification and resolves command enums.
+func (s *spec) check() error {
+	enums := make(map[string]*enum)
+	idents := make(map[string]string)
+	declare := func(ident, what string) error {
+		if !identPattern.MatchString(ident) {
+			return fmt.Errorf("%v: invalid identifier %q", what, ident)
+		}
+		if other, ok := idents[ident]; ok {
+			return fmt.Errorf("%v: identifier %v already used by %v", what, ident, other)
+		}
+		idents[ident] = what
+		return nil
+	}
+	checkEnum := func(e *enum) error {
+		if len(e.Values) == 0 {
+			return fmt.Errorf("enum %v has no values", e.Name)
+		}
+		if err := declare(e.Name, "enum "+e.Name); err != nil {
+			return err
+		}
+		params := make(map[string]bool)
+		for _, v := range e.Values {
+			if v.Param == "" || v.Name == "" {
+				return fmt.Errorf("enum %v: value missing param or name", e.Name)
+			}
+			if params[v.Param] {
+				return fmt.Errorf("enum %v: duplicate param %q", e.Name, v.Param)
+			}
+			params[v.Param] = true
+			if v.Ident == "" {
+				v.Ident = identifier(v.Name)
+			}
+			if err := declare(e.Name+v.Ident, "enum "+e.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, e := range s.Enums {
+		if _, ok := enums[e.Name]; ok {
+			return fmt.Errorf("duplicate enum %v", e.Name)
+		}
+		if err := checkEnum(e); err != nil {
+			return err
+		}
+		enums[e.Name] = e
+	}
+	codes := make(map[string]bool)
+	for _, c := range s.Commands {
+		if !codePattern.MatchString(c.Code) {
+			return fmt.Errorf("invalid command code %q", c.Code)
+		}
+		if codes[c.Code] {
+			return fmt.Errorf("duplicate command %v", c.Code)
+		}
+		codes[c.Code] = true
+		if c.Name == "" {
+			return fmt.Errorf("command %v has no name", c.Code)
+		}
+		if _, ok := zones[c.Zone]; !ok {
+			return fmt.Errorf("command %v: unknown zone %q", c.Code, c.Zone)
+		}
+		if !identPattern.MatchString(c.Ident) {
+			return fmt.Errorf("command %v: invalid identifier %q", c.Code, c.Ident)
+		}
+		if err := declare("Cmd"+c.Ident, "command "+c.Code); err != nil {
+			return err
+		}
+		switch {
+		case c.Enum != "" && len(c.Values) > 0:
+			return fmt.Errorf("command %v has both enum and values", c.Code)
+		case c.Enum != "":
+			e, ok := enums[c.Enum]
+			if !ok {
+				return fmt.Errorf("command %v: unknown enum %v", c.Code, c.Enum)
+			}
+			c.enum = e
+		case len(c.Values) > 0:
+			c.enum = &enum{
+				Name:   c.Ident,
+				Doc:    fmt.Sprintf("%v is the setting of the %v command.", c.Ident, c.Name),
+				Values: c.Values,
+			}
+			if err := checkEnum(c.enum); err != nil {
+				return err
+			}
+		}
+		if c.enum != nil && c.Range != nil {
+			return fmt.Errorf("command %v has both enumerated values and a range", c.Code)
+		}
+		for _, param := range c.Exclude {
+			if c.enum == nil || c.enum.value(param) == nil {
+				return fmt.Errorf("command %v: excluded param %q not enumerated", c.Code, param)
+			}
+		}
+		if r := c.Range; r != nil && (r.Min < 0 || r.Min > r.Max || r.Width < 1 || r.Max >= 1<<(4*uint(r.Width))) {
+			return fmt.Errorf("command %v: invalid range", c.Code)
+		}
+		if c.Pattern != "" {
+			if _, err := regexp.Compile(c.Pattern); err != nil {
+				return fmt.Errorf("command %v: %v", c.Code, err)
+			}
+		}
+		if c.Range != nil {
+			if err := declare(c.Ident+"Message", "command "+c.Code); err != nil {
+				return err
+			}
+			if err := declare("Decode"+c.Ident, "command "+c.Code); err != nil {
+				return err
+			}
+		} else if c.enum != nil {
+			if err := declare(c.Ident+"Message", "command "+c.Code); err != nil {
+				return err
+			}
+		}
+	}
+	if len(s.Commands) == 0 {
+		return errors.New("no commands")
+	}
+	return nil
+}
+
+func (e *enum) value(param string) *value {
+	for _, v := range e.Values {
+		if v.Param == param {
+			return v
+		}
+	}
+	return nil
+}
+
+// AllEnums returns the shared enums followed by the commands' own enums.
+func (s *spec) AllEnums() []*enum {
+	enums := append([]*enum(nil), s.Enums...)
+	for _, c := range s.Commands {
+		if len(c.Values) > 0 {
+			enums = append(enums, c.enum)
+		}
+	}
+	return enums
+}
+
+// Params returns the enumerated and extra parameters accepted by the
+// command in the order they appear in the catalog.
+func (c *command) Params() []*value {
+	var params []*value
+	if c.enum != nil {
+		excluded := make(map[string]bool)
+		for _, param := range c.Exclude {
+			excluded[param] = true
+		}
+		for _, v := range c.enum.Values {
+			if !excluded[v.Param] {
+				params = append(params, v)
+			}
+		}
+	}
+	return append(params, c.Extra...)
+}
+
+// EnumType returns the name of the type of the command's enumerated
+// parameters, or "" if it has none.
+func (c *command) EnumType() string {
+	if c.enum == nil {
+		return ""
+	}
+	return c.enum.Name
+}
+
+func (c *command) ZoneID() string {
+	return zones[c.Zone]
+}
+
+// Accepts describes the parameters accepted by the command.
+func (c *command) Accepts() string {
+	var accepts []string
+	if r := c.Range; r != nil {
+		accepts = append(accepts, fmt.Sprintf("%0*X through %0*X", r.Width, r.Min, r.Width, r.Max))
+	}
+	if c.enum != nil {
+		accepts = append(accepts, c.enum.Name+" values")
+	}
+	for _, v := range c.Extra {
+		accepts = append(accepts, v.Param)
+	}
+	if c.Syntax != "" {
+		accepts = append(accepts, c.Syntax)
+	} else if c.Pattern != "" {
+		accepts = append(accepts, "parameters matching "+c.Pattern)
+	}
+	if c.Up {
+		accepts = append(accepts, "UP")
+	}
+	if c.Down {
+		accepts = append(accepts, "DOWN")
+	}
+	if c.Query {
+		accepts = append(accepts, "QSTN")
+	}
+	switch len(accepts) {
+	case 0:
+		return "It is only sent by the device."
+	case 1:
+		return "It accepts " + accepts[0] + "."
+	}
+	return "It accepts " + strings.Join(accepts[:len(accepts)-1], ", ") + " and " + accepts[len(accepts)-1] + "."
+}
+
+// identifier returns a Go identifier made from the words of name,
+// e.g., "Bright & LED Off" becomes "BrightLEDOff".
+func identifier(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// comment formats text as a Go comment wrapped at 70 columns.
+func comment(indent int, text string) string {
+	var lines []string
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word)+indent > 70 && line != "//" {
+			lines = append(lines, line)
+			line = "//"
+		}
+		line += " " + word
+	}
+	lines = append(lines, line)
+	return strings.Join(lines, "\n"+strings.Repeat("\t", indent))
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+var generated = template.Must(template.New("generated").Funcs(template.FuncMap{
+	"comment":    comment,
+	"lowerFirst": lowerFirst,
+	"printf":     fmt.Sprintf,
+}).Parse(`// Code generated by integragen from {{.Source}}. DO NOT EDIT.
+
+package {{.Package}}
+
+import "fmt"
+
+// ISCP command codes.
+const (
+{{- range .Spec.Commands}}
+	{{comment 1 (printf "Cmd%v is the %v command, %q. %v" .Ident .Name .Code .Accepts)}}
+	Cmd{{.Ident}} = "{{.Code}}"
+{{- end}}
+)
+
+// catalog lists the ISCP commands described in {{.Source}}.
+var catalog = []Command{
+{{- range .Spec.Commands}}
+	{
+		Code: Cmd{{.Ident}},
+		Name: {{printf "%q" .Name}},
+		{{- if ne .ZoneID "MainZone"}}
+		Zone: {{.ZoneID}},
+		{{- end}}
+		{{- if .Range}}
+		Range: &{{lowerFirst .Ident}}Range,
+		{{- end}}
+		{{- with .Params}}
+		Values: []Value{
+			{{- range .}}
+			{ {{- printf "%q" .Param}}, {{printf "%q" .Name -}} },
+			{{- end}}
+		},
+		{{- end}}
+		{{- if .Pattern}}
+		Pattern: {{printf "%#q" .Pattern}},
+		{{- end}}
+		{{- if .Query}}
+		Query: true,
+		{{- end}}
+		{{- if .Up}}
+		Up: true,
+		{{- end}}
+		{{- if .Down}}
+		Down: true,
+		{{- end}}
+	},
+{{- end}}
+}
+{{range .Spec.AllEnums}}{{$enum := .}}
+{{comment 0 .Doc}}
+type {{.Name}} string
+
+// {{.Name}} values.
+const (
+{{- range .Values}}
+	{{comment 1 (printf "%v%v is %q." $enum.Name .Ident .Name)}}
+	{{$enum.Name}}{{.Ident}} {{$enum.Name}} = {{printf "%q" .Param}}
+{{- end}}
+)
+
+var {{lowerFirst .Name}}Names = map[{{.Name}}]string{
+{{- range .Values}}
+	{{$enum.Name}}{{.Ident}}: {{printf "%q" .Name}},
+{{- end}}
+}
+
+// String returns the human-readable name of v.
+func (v {{.Name}}) String() string {
+	if name, ok := {{lowerFirst .Name}}Names[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("{{.Name}}(%q)", string(v))
+}
+
+// Parse{{.Name}} returns the {{.Name}} value encoded by param.
+func Parse{{.Name}}(param string) ({{.Name}}, error) {
+	v := {{.Name}}(param)
+	if _, ok := {{lowerFirst .Name}}Names[v]; !ok {
+		return "", fmt.Errorf("%w %q for {{.Name}}", ErrInvalidParameter, param)
+	}
+	return v, nil
+}
+{{end}}
+{{- range .Spec.Commands}}
+{{- if .Range}}
+var {{lowerFirst .Ident}}Range = Range{ {{- printf "0x%02X" .Range.Min}}, {{printf "0x%02X" .Range.Max}}, {{.Range.Width -}} }
+
+{{comment 0 (printf "%vMessage returns a message setting the %v to v, which must be between %v and %v." .Ident .Name .Range.Min .Range.Max)}}
+func {{.Ident}}Message(v int) (*Message, error) {
+	param, err := {{lowerFirst .Ident}}Range.Encode(v)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", Cmd{{.Ident}}, err)
+	}
+	return &Message{Cmd{{.Ident}}, param}, nil
+}
+
+{{comment 0 (printf "Decode%v returns the %v value encoded by param." .Ident .Name)}}
+func Decode{{.Ident}}(param string) (int, error) {
+	return {{lowerFirst .Ident}}Range.Decode(param)
+}
+{{else if .EnumType}}
+{{comment 0 (printf "%vMessage returns a message setting the %v to v." .Ident .Name)}}
+func {{.Ident}}Message(v {{.EnumType}}) *Message {
+	return &Message{Cmd{{.Ident}}, string(v)}
+}
+{{end}}
+{{- end}}
+`))
+
+func generate(s *spec, source, pkg string) ([]byte, error) {
+	var buf bytes.Buffer
+	err := generated.Execute(&buf, struct {
+		Source, Package string
+		Spec            *spec
+	}{source, pkg, s})
+	if err != nil {
+		return nil, err
+	}
+	b, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return b, nil
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("integragen: ")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: integragen [-o output] [-package name] spec.json")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	s, err := readSpec(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, err := generate(s, path, *packageName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(b)
+	} else {
+		err = ioutil.WriteFile(*output, b, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	s, err := readSpec("../../commands.json")
+	if err != nil {
+		t.Fatal("readSpec failed:", err)
+	}
+	b, err := generate(s, "commands.json", "integra")
+	if err != nil {
+		t.Fatal("generate failed:", err)
+	}
+	existing, err := ioutil.ReadFile("../../commands_gen.go")
+	if err != nil {
+		t.Fatal("ReadFile failed:", err)
+	}
+	if !bytes.Equal(b, existing) {
+		t.Error("commands_gen.go is out of date; run go generate")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		spec string
+		err  string
+	}{
+		{`{"commands": [{"code": "PWR", "ident": "Power", "name": "Power", "query": true}]}`, ""},
+		{`{"commands": []}`, "no commands"},
+		{`{"commands": [{"code": "pwr", "ident": "Power", "name": "Power"}]}`, "invalid command code"},
+		{`{"commands": [{"code": "PWR", "ident": "power", "name": "Power"}]}`, "invalid identifier"},
+		{`{"commands": [{"code": "PWR", "ident": "Power", "name": "Power"}, {"code": "PWR", "ident": "Power2", "name": "Power"}]}`, "duplicate command"},
+		{`{"commands": [{"code": "PWR", "ident": "Power", "name": "Power"}, {"code": "ZPW", "ident": "Power", "name": "Power"}]}`, "already used"},
+		{`{"commands": [{"code": "PWR", "ident": "Power", "name": "Power", "zone": "zone5"}]}`, "unknown zone"},
+		{`{"commands": [{"code": "PWR", "ident": "Power", "name": "Power", "enum": "Power"}]}`, "unknown enum"},
+		{`{"commands": [{"code": "MVL", "ident": "Volume", "name": "Volume", "range": {"min": 0, "max": 256, "width": 2}}]}`, "invalid range"},
+		{`{"commands": [{"code": "TUN", "ident": "Tuner", "name": "Tuner", "pattern": "[0-9"}]}`, "missing closing ]"},
+		{`{"commands": [{"code": "DIM", "ident": "Dimmer", "name": "Dimmer", "values": [{"param": "00", "name": "Bright"}, {"param": "00", "name": "Dim"}]}]}`, "duplicate param"},
+		{`{"commands": [{"code": "SLI", "ident": "Input", "name": "Input", "values": [{"param": "00", "name": "VCR"}], "exclude": ["7F"]}]}`, "not enumerated"},
+	}
+	for _, tt := range tests {
+		var s spec
+		if err := json.Unmarshal([]byte(tt.spec), &s); err != nil {
+			t.Fatalf("Unmarshal(%v) failed: %v", tt.spec, err)
+		}
+		err := s.check()
+		if tt.err == "" && err != nil {
+			t.Errorf("check(%v) returned %v, expected nil", tt.spec, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("check(%v) returned %v, expected error containing %q", tt.spec, err, tt.err)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := []struct {
+		name, ident string
+	}{
+		{"Bright & LED Off", "BrightLEDOff"},
+		{"4:3", "43"},
+		{"1080p/24fs", "1080p24fs"},
+		{"iLINK", "ILINK"},
+	}
+	for _, tt := range tests {
+		if ident := identifier(tt.name); ident != tt.ident {
+			t.Errorf("identifier(%q) returned %q, expected %q", tt.name, ident, tt.ident)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// ErrNotConfirmed is returned by SendConfirm when the Integra device
+// doesn't report the value sent after every attempt.
+var ErrNotConfirmed = errors.New("not confirmed")
+
+const (
+	defaultConfirmAttempts = 3
+	defaultConfirmTimeout  = time.Second
+	defaultConfirmBackoff  = 200 * time.Millisecond
+)
+
+// A ConfirmOption configures SendConfirm.
+type ConfirmOption func(*confirmOptions)
+
+type confirmOptions struct {
+	attempts int
+	timeout  time.Duration
+	backoff  time.Duration
+}
+
+// Attempts sets the number of times SendConfirm sends the message
+// before giving up. The default is 3; numbers less than 1 are treated
+// as 1.
+func Attempts(n int) ConfirmOption {
+	return func(o *confirmOptions) {
+		if n < 1 {
+			n = 1
+		}
+		o.attempts = n
+	}
+}
+
+// ConfirmTimeout sets how long SendConfirm waits for the device to
+// report the value after each attempt. The default is one second.
+func ConfirmTimeout(timeout time.Duration) ConfirmOption {
+	return func(o *confirmOptions) {
+		o.timeout = timeout
+	}
+}
+
+// RetryBackoff sets the delay before the second attempt; the delay
+// doubles before each later attempt. The default is 200 milliseconds.
+func RetryBackoff(backoff time.Duration) ConfirmOption {
+	return func(o *confirmOptions) {
+		o.backoff = backoff
+	}
+}
+
+// confirms reports whether reply from the Integra device shows that
+// sent was applied. Step, toggle and cycle messages such as MVLUP
+// are confirmed by any value since the resulting value isn't known.
+func confirms(sent, reply *Message) bool {
+	if reply.Command != sent.Command || reply.NotAvailable() {
+		return false
+	}
+	if stepParams[sent.Parameter] || cycleParams[sent.Parameter] {
+		return true
+	}
+	return strings.EqualFold(reply.Parameter, sent.Parameter)
+}
+
+// SendConfirm sends the message to the Integra device and waits for
+// the device to report the value sent, e.g., MVL2A for MVL2A. The
+// device often ignores messages, for example while powering up, so
+// SendConfirm resends the message until it is confirmed or the
+// attempts run out, in which case it returns an error wrapping
+// ErrNotConfirmed.
+//
+// Step, toggle and cycle messages such as MVLUP aren't safe to
+// repeat, so they are sent only once; SendConfirm then just waits for
+// the device to report the new value. If CoalesceCommands replaces
+// the message by a newer one, SendConfirm stops and returns an error
+// wrapping ErrSuperseded rather than overwriting the newer value.
+//
+// QSTN messages can't be confirmed; use Query instead.
+func (c *Client) SendConfirm(ctx context.Context, m *Message, opts ...ConfirmOption) error {
+	if m.Parameter == "QSTN" {
+		return fmt.Errorf("%v: use Query to send queries", m)
+	}
+	o := confirmOptions{
+		attempts: defaultConfirmAttempts,
+		timeout:  defaultConfirmTimeout,
+		backoff:  defaultConfirmBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if stepParams[m.Parameter] || cycleParams[m.Parameter] {
+		o.attempts = 1
+	}
+	// Subscribe before sending so that a prompt reply isn't
+	// missed. Only the latest value matters, so older ones are
+	// dropped rather than disconnecting when the buffer fills.
+	replies := c.device.Subscribe(CommandFilter(m.Command), Overflow(DropOldest))
+	defer replies.Close()
+	backoff := o.backoff
+	for attempt := 1; attempt <= o.attempts; attempt++ {
+		if attempt > 1 {
+			log.Printf("Resending unconfirmed message %v (attempt %v)\n", m, attempt)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			backoff *= 2
+		}
+		err := c.send(ctx, m, priorityOf(m))
+		if err == ErrSuperseded {
+			return fmt.Errorf("%v: %w", m, ErrSuperseded)
+		}
+		if err != nil {
+			return err
+		}
+		ok, err := awaitConfirmation(ctx, replies, m, o.timeout)
+		if err != nil || ok {
+			return err
+		}
+	}
+	return fmt.Errorf("%v: %w after %v attempts", m, ErrNotConfirmed, o.attempts)
+}
+
+// awaitConfirmation waits up to timeout for a message from replies
+// confirming m.
+func awaitConfirmation(ctx context.Context, replies *Client, m *Message, timeout time.Duration) (bool, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		reply, err := replies.ReceiveContext(attemptCtx)
+		if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+			// Only this attempt timed out.
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if confirms(m, reply) {
+			return true, nil
+		}
+	}
+}
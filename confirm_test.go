@@ -0,0 +1,159 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfirms(t *testing.T) {
+	tests := []struct {
+		sent, reply string
+		expected    bool
+	}{
+		{"MVL2A", "MVL2A", true},
+		{"MVL2a", "MVL2A", true},
+		{"MVL2A", "MVL20", false},
+		{"MVL2A", "AMT00", false},
+		{"MVL2A", "MVLN/A", false},
+		{"MVLUP", "MVL21", true},
+		{"AMTTG", "AMT01", true},
+		{"PWR01", "PWR01", true},
+	}
+	for _, tt := range tests {
+		sent := &Message{tt.sent[:3], tt.sent[3:]}
+		reply := &Message{tt.reply[:3], tt.reply[3:]}
+		if actual := confirms(sent, reply); actual != tt.expected {
+			t.Errorf("confirms(%v, %v) returned %v, expected %v", sent, reply, actual, tt.expected)
+		}
+	}
+}
+
+func TestSendConfirm(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	// The device ignores the first MVL20 and reports MVL10 after
+	// the second; it applies the third.
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case m := <-transport.out:
+				switch i {
+				case 1:
+					transport.in <- &Message{m.Command, "10"}
+				case 2:
+					transport.in <- m
+				}
+			case <-transport.closed:
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	client := device.NewSendOnlyClient()
+	err = client.SendConfirm(ctx, &Message{"MVL", "20"},
+		ConfirmTimeout(30*time.Millisecond), RetryBackoff(time.Millisecond))
+	if err != nil {
+		t.Error("SendConfirm failed:", err)
+	}
+
+	err = client.SendConfirm(ctx, &Message{"MVL", "20"},
+		Attempts(2), ConfirmTimeout(10*time.Millisecond), RetryBackoff(0))
+	if !errors.Is(err, ErrNotConfirmed) {
+		t.Errorf("SendConfirm returned %v, expected %v", err, ErrNotConfirmed)
+	}
+
+	if err := client.SendConfirm(ctx, &Message{"MVL", "QSTN"}); err == nil {
+		t.Error("SendConfirm(MVLQSTN) succeeded, expected error")
+	}
+}
+
+func TestSendConfirmContext(t *testing.T) {
+	device, err := ConnectTransport(fakeDialer(newFakeTransport()), CommandGap(0))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client := device.NewSendOnlyClient()
+	if err := client.SendConfirm(ctx, &Message{"PWR", "01"}, Attempts(1)); err != context.DeadlineExceeded {
+		t.Errorf("SendConfirm returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSendConfirmStep(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	client := device.NewSendOnlyClient()
+	// The device ignores MVLUP, which mustn't be resent.
+	err = client.SendConfirm(ctx, &Message{"MVL", "UP"},
+		Attempts(3), ConfirmTimeout(10*time.Millisecond), RetryBackoff(0))
+	if !errors.Is(err, ErrNotConfirmed) {
+		t.Errorf("SendConfirm returned %v, expected %v", err, ErrNotConfirmed)
+	}
+	transport.expectWritten(t, "MVLUP")
+	select {
+	case m := <-transport.out:
+		t.Errorf("Wrote %v, expected nothing", m)
+	default:
+	}
+}
+
+func TestSendConfirmSuperseded(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport),
+		CommandGap(100*time.Millisecond), CoalesceCommands(time.Hour))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"PWR", "01"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	// MVL20 waits for the gap after PWR01 and is replaced by MVL21.
+	errs := make(chan error, 1)
+	go func() { errs <- client.SendConfirm(ctx, &Message{"MVL", "20"}) }()
+	waitQueueDepth(t, device, 1)
+	if err := client.Send(&Message{"MVL", "21"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	if err := <-errs; !errors.Is(err, ErrSuperseded) {
+		t.Errorf("SendConfirm returned %v, expected %v", err, ErrSuperseded)
+	}
+	transport.expectWritten(t, "PWR01")
+	transport.expectWritten(t, "MVL21")
+}
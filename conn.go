@@ -0,0 +1,182 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 30 * time.Second
+	// defaultWriteTimeout bounds writes to a stuck connection.
+	defaultWriteTimeout = 10 * time.Second
+)
+
+// A ConnState is the state of a Device's connection to the Integra
+// device.
+type ConnState int
+
+const (
+	// Connected means messages can be sent to and received from
+	// the Integra device.
+	Connected ConnState = iota
+	// Disconnected means the connection was lost or a reconnect
+	// attempt failed. Another attempt will follow after a delay.
+	Disconnected
+	// Connecting means a reconnect attempt is in progress.
+	Connecting
+	// Closed means the Device was closed. It is the final state.
+	Closed
+)
+
+func (s ConnState) String() string {
+	switch s {
+	case Connected:
+		return "connected"
+	case Disconnected:
+		return "disconnected"
+	case Connecting:
+		return "connecting"
+	case Closed:
+		return "closed"
+	}
+	return fmt.Sprintf("ConnState(%d)", int(s))
+}
+
+// A ConnEvent reports a change in the state of a Device's connection.
+type ConnEvent struct {
+	State ConnState
+	// Err is the reason for the change to Disconnected, if any.
+	Err  error
+	Time time.Time
+}
+
+func (e ConnEvent) String() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v (%v)", e.State, e.Err)
+	}
+	return e.State.String()
+}
+
+// ConnState returns the current state of the connection to the
+// Integra device.
+func (d *Device) ConnState() ConnState {
+	d.conn.Lock()
+	defer d.conn.Unlock()
+	return d.conn.state
+}
+
+// NotifyConnState causes connection state changes to be sent on c.
+// The Device does not block sending to c; the caller must ensure c
+// has sufficient buffer space to keep up.
+func (d *Device) NotifyConnState(c chan<- ConnEvent) {
+	d.conn.Lock()
+	defer d.conn.Unlock()
+	d.conn.notify = append(d.conn.notify, c)
+}
+
+// transport returns the current Transport, or nil if the Device is
+// not connected.
+func (d *Device) transport() Transport {
+	d.conn.Lock()
+	defer d.conn.Unlock()
+	if d.conn.state != Connected {
+		return nil
+	}
+	return d.conn.transport
+}
+
+// setConnState records a connection state change and notifies
+// interested parties. It returns the Transport being replaced, and
+// reports false if the Device has already been closed, in which case
+// the state is not changed.
+func (d *Device) setConnState(state ConnState, t Transport, err error) (Transport, bool) {
+	d.conn.Lock()
+	defer d.conn.Unlock()
+	if d.conn.state == Closed {
+		return nil, false
+	}
+	previous := d.conn.transport
+	d.conn.state = state
+	d.conn.transport = t
+	event := ConnEvent{state, err, time.Now()}
+	log.Println("Connection", event)
+	for _, c := range d.conn.notify {
+		select {
+		case c <- event:
+		default:
+		}
+	}
+	return previous, true
+}
+
+// reconnect dials the Integra device until a connection is
+// established, backing off exponentially between attempts, and
+// returns the new Transport. It returns nil if the Device is closed.
+func (d *Device) reconnect() Transport {
+	delay := d.minBackoff
+	for {
+		log.Printf("Reconnecting in %v\n", delay)
+		select {
+		case <-time.After(delay):
+		case <-d.done:
+			return nil
+		}
+		if _, ok := d.setConnState(Connecting, nil, nil); !ok {
+			return nil
+		}
+		t, err := d.dial(d.ctx)
+		if err == nil {
+			if _, ok := d.setConnState(Connected, t, nil); !ok {
+				_ = t.Close()
+				return nil
+			}
+			go d.requery()
+			return t
+		}
+		if _, ok := d.setConnState(Disconnected, nil, err); !ok {
+			return nil
+		}
+		delay *= 2
+		if delay > d.maxBackoff {
+			delay = d.maxBackoff
+		}
+	}
+}
+
+// requery sends a QSTN message for each command in the state so that
+// changes made while disconnected are picked up.
+func (d *Device) requery() {
+	d.state.RLock()
+	commands := make([]string, 0, len(d.state.m))
+	for command, entry := range d.state.m {
+		if entry.Parameter != "" {
+			commands = append(commands, command)
+		}
+	}
+	d.state.RUnlock()
+	sort.Strings(commands)
+
+	for _, command := range commands {
+		if err := d.sendMessage(d.ctx, &Message{command, "QSTN"}, NormalPriority); err != nil {
+			log.Println("Requery failed:", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import "errors"
+
+// ErrUnknownState is returned by Client getters such as Volume when
+// the Integra device hasn't reported the value since the Device
+// connected. Use Query or send a QSTN message to request it.
+var ErrUnknownState = errors.New("state unknown")
+
+// The methods below control the main zone. Use Zone to control the
+// other zones.
+
+// SetPower turns the Integra device on or puts it in standby.
+func (c *Client) SetPower(on bool) error {
+	return c.Zone(MainZone).SetPower(on)
+}
+
+// Power reports whether the Integra device is on.
+func (c *Client) Power() (bool, error) {
+	return c.Zone(MainZone).Power()
+}
+
+// SetVolume sets the master volume, which must be between 0 and 100.
+func (c *Client) SetVolume(volume int) error {
+	return c.Zone(MainZone).SetVolume(volume)
+}
+
+// Volume returns the master volume.
+func (c *Client) Volume() (int, error) {
+	return c.Zone(MainZone).Volume()
+}
+
+// VolumeUp raises the master volume by one step.
+func (c *Client) VolumeUp() error {
+	return c.Zone(MainZone).VolumeUp()
+}
+
+// VolumeDown lowers the master volume by one step.
+func (c *Client) VolumeDown() error {
+	return c.Zone(MainZone).VolumeDown()
+}
+
+// SetMute mutes or unmutes the audio.
+func (c *Client) SetMute(mute bool) error {
+	return c.Zone(MainZone).SetMute(mute)
+}
+
+// Muted reports whether the audio is muted.
+func (c *Client) Muted() (bool, error) {
+	return c.Zone(MainZone).Muted()
+}
+
+// SelectInput selects the input source.
+func (c *Client) SelectInput(input Input) error {
+	return c.Zone(MainZone).SelectInput(input)
+}
+
+// Input returns the selected input source.
+func (c *Client) Input() (Input, error) {
+	return c.Zone(MainZone).Input()
+}
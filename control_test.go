@@ -0,0 +1,80 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestControl(t *testing.T) {
+	transport := newFakeTransport()
+	go transport.serve(map[string]string{})
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewClient()
+	defer client.Close()
+	messages := collect(client)
+
+	if _, err := client.Volume(); !errors.Is(err, ErrUnknownState) {
+		t.Errorf("Volume returned %v, expected %v", err, ErrUnknownState)
+	}
+
+	if err := client.SetPower(true); err != nil {
+		t.Fatal("SetPower failed:", err)
+	}
+	expectReceived(t, messages, "PWR01")
+	if on, err := client.Power(); err != nil || !on {
+		t.Errorf("Power returned %v, %v, expected true", on, err)
+	}
+
+	if err := client.SetVolume(42); err != nil {
+		t.Fatal("SetVolume failed:", err)
+	}
+	expectReceived(t, messages, "MVL2A")
+	if v, err := client.Volume(); err != nil || v != 42 {
+		t.Errorf("Volume returned %v, %v, expected 42", v, err)
+	}
+	if err := client.SetVolume(101); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("SetVolume(101) returned %v, expected %v", err, ErrInvalidParameter)
+	}
+
+	if err := client.SetMute(true); err != nil {
+		t.Fatal("SetMute failed:", err)
+	}
+	expectReceived(t, messages, "AMT01")
+	if muted, err := client.Muted(); err != nil || !muted {
+		t.Errorf("Muted returned %v, %v, expected true", muted, err)
+	}
+
+	if err := client.SelectInput(InputDVDBD); err != nil {
+		t.Fatal("SelectInput failed:", err)
+	}
+	expectReceived(t, messages, "SLI10")
+	if input, err := client.Input(); err != nil || input != InputDVDBD {
+		t.Errorf("Input returned %v, %v, expected %v", input, err, InputDVDBD)
+	}
+	if err := client.SelectInput(InputSource); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("SelectInput(InputSource) returned %v, expected %v", err, ErrInvalidParameter)
+	}
+
+	if err := client.VolumeUp(); err != nil {
+		t.Fatal("VolumeUp failed:", err)
+	}
+	expectReceived(t, messages, "MVLUP")
+}
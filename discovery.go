@@ -0,0 +1,154 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// discoveryPort is the UDP port on which Integra devices
+	// listen for discovery queries.
+	discoveryPort = 60128
+	// discoveryTimeout is how long Discover waits for replies when
+	// its context has no deadline.
+	discoveryTimeout = 2 * time.Second
+	// discoveryQuery asks every device on the network to identify
+	// itself. It is sent with unit type 'x' (any device) rather
+	// than '1' (receiver).
+	discoveryQuery = "ECNQSTN"
+)
+
+// A DiscoveredDevice describes an Integra device that replied to
+// Discover.
+type DiscoveredDevice struct {
+	// Model is the device's model name, e.g., "DTR-40.5".
+	Model string
+	// Address is the TCP address of the device's eISCP service,
+	// suitable for passing to Connect.
+	Address string
+	// Region is the device's destination area: "DX" (North
+	// America), "XX" (Europe or Asia) or "JJ" (Japan).
+	Region string
+	// MAC is the device's identifier, usually its MAC address
+	// written as 12 hex digits, e.g., "0009B0D4A5F1".
+	MAC string
+}
+
+// Discover broadcasts an eISCP discovery query on the local network
+// and returns the devices that reply before ctx is done. If ctx has no
+// deadline, Discover waits two seconds for replies.
+func Discover(ctx context.Context) ([]DiscoveredDevice, error) {
+	return discover(ctx, &net.UDPAddr{IP: net.IPv4bcast, Port: discoveryPort})
+}
+
+// discover sends the discovery query to addr and collects replies.
+func discover(ctx context.Context, addr *net.UDPAddr) ([]DiscoveredDevice, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, discoveryTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// Unblock ReadFromUDP if ctx is canceled before the deadline.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	query := newEISCPPacket(discoveryQuery)
+	query[headerSize+1] = 'x'
+	if _, err := conn.WriteToUDP(query, addr); err != nil {
+		return nil, err
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var devices []DiscoveredDevice
+	seen := make(map[string]bool)
+	buffer := make([]byte, 1024)
+	for {
+		n, from, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			if e, ok := err.(net.Error); ok && e.Timeout() {
+				return devices, nil
+			}
+			return devices, err
+		}
+		device, err := parseDiscoveryReply(eISCPPacket(buffer[:n]), from)
+		if err != nil {
+			log.Printf("Bad discovery reply from %v: %v", from, err)
+			continue
+		}
+		if seen[device.Address] {
+			continue
+		}
+		seen[device.Address] = true
+		log.Printf("Discovered %v at %v", device.Model, device.Address)
+		devices = append(devices, *device)
+	}
+}
+
+// parseDiscoveryReply parses a reply to the discovery query received
+// from the given address. The reply's ECN parameter has the form
+// "model/port/region/identifier", optionally followed by 0x19.
+func parseDiscoveryReply(p eISCPPacket, from *net.UDPAddr) (*DiscoveredDevice, error) {
+	if err := checkHeader(p); err != nil {
+		return nil, err
+	}
+	if len(p) < headerSize+dataSize(p) {
+		return nil, fmt.Errorf("data size %v does not match actual size %v",
+			dataSize(p), len(p)-headerSize)
+	}
+	m, err := eISCPPacket(p[:headerSize+dataSize(p)]).message()
+	if err != nil {
+		return nil, err
+	}
+	if m.Command != "ECN" {
+		return nil, fmt.Errorf("unexpected message %v", m)
+	}
+	fields := strings.Split(strings.TrimRight(m.Parameter, "\x19"), "/")
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("malformed ECN parameter %q", m.Parameter)
+	}
+	port, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed port %q", fields[1])
+	}
+	return &DiscoveredDevice{
+		Model:   fields[0],
+		Address: net.JoinHostPort(from.IP.String(), strconv.Itoa(port)),
+		Region:  fields[2],
+		MAC:     fields[3],
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParseDiscoveryReply(t *testing.T) {
+	from := &net.UDPAddr{IP: net.IPv4(192, 168, 1, 20), Port: 60128}
+	tests := []struct {
+		message  string
+		expected *DiscoveredDevice
+	}{
+		{"ECNTX-NR609/60128/DX/0009B0D4A5F1\x19\r",
+			&DiscoveredDevice{"TX-NR609", "192.168.1.20:60128", "DX", "0009B0D4A5F1"}},
+		{"ECNDTR-40.5/60129/XX/0009B0123456",
+			&DiscoveredDevice{"DTR-40.5", "192.168.1.20:60129", "XX", "0009B0123456"}},
+		{"ECNDTR-40.5/60128/XX", nil},
+		{"ECNDTR-40.5/port/XX/0009B0123456", nil},
+		{"PWR01", nil},
+	}
+	for _, test := range tests {
+		result, err := parseDiscoveryReply(rxPacket(test.message), from)
+		if test.expected == nil {
+			if err == nil {
+				t.Errorf("%q: expected non-nil error", test.message)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: parseDiscoveryReply failed: %v", test.message, err)
+		} else if *result != *test.expected {
+			t.Errorf("%q: %+v did not match expected %+v", test.message, *result, *test.expected)
+		}
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	responder, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal("ListenUDP failed:", err)
+	}
+	defer responder.Close()
+	go func() {
+		buffer := make([]byte, 64)
+		n, from, err := responder.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+		if string(eISCPPacket(buffer[:n]).data()) != "!xECNQSTN" {
+			t.Errorf("unexpected query %q", buffer[:n])
+			return
+		}
+		reply := rxPacket("ECNTX-NR609/60128/DX/0009B0D4A5F1")
+		// Duplicate replies are reported once.
+		_, _ = responder.WriteToUDP(reply, from)
+		_, _ = responder.WriteToUDP(reply, from)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	devices, err := discover(ctx, responder.LocalAddr().(*net.UDPAddr))
+	if err != nil {
+		t.Fatal("discover failed:", err)
+	}
+	expected := DiscoveredDevice{"TX-NR609", "127.0.0.1:60128", "DX", "0009B0D4A5F1"}
+	if len(devices) != 1 || devices[0] != expected {
+		t.Errorf("%+v did not match expected [%+v]", devices, expected)
+	}
+}
@@ -16,7 +16,7 @@
 
 Package integra provides a client to communicate with an Integra (or
 Onkyo) A/V receiver device using the Integra Serial Control Protocol
-over Ethernet (eISCP).
+over Ethernet (eISCP) or over an RS-232 serial port (ISCP).
 
 Example usage:
 
@@ -27,6 +27,134 @@ Example usage:
   message, _ = client.Receive()
   fmt.Println("Got message from Integra A/V receiver:", message)
   client.Close()
+  device.Close()
+
+If the connection to the device is lost, the Device reconnects with
+exponential backoff; clients stay attached and the known state is
+refreshed once the connection is back. Use NotifyConnState to follow
+connection state changes.
+
+Messages are checked against a built-in catalog of ISCP commands
+before they are sent, so a typo like MVL999 fails locally with an
+error wrapping ErrInvalidParameter. Use LookupCommand to inspect the
+catalog and the SkipValidation option to send commands it doesn't
+cover.
+
+The catalog and typed helpers such as MasterVolumeMessage,
+InputSelectorMessage and the Input constants are generated from
+commands.json by cmd/integragen. To add a command, edit commands.json
+and run go generate.
+
+Client also offers typed methods for common operations so that
+parameters don't have to be encoded by hand. Getters like Volume
+report the state last received from the device:
+
+  client.SetPower(true)
+  client.SetVolume(30)
+  client.SelectInput(integra.InputDVDBD)
+  volume, err := client.Volume()
+
+Zones 2, 3 and 4 of multi-zone receivers are controlled with the
+same methods through Client.Zone:
+
+  client.Zone(integra.Zone2).SetVolume(20)
+
+Client.DeviceState decodes the known state into a DeviceState with
+per-zone power, volume, input, tone and tuner fields. To react to
+state transitions instead of polling, use NotifyChanges (the channel
+is closed when the Device is closed):
+
+  changes := make(chan integra.Change, 16)
+  device.NotifyChanges(changes)
+  for change := range changes {
+      fmt.Println(change.Command, change.Old, "->", change.New)
+  }
+
+Clients returned by NewClient receive every message from the device.
+To receive only some commands, e.g., a zone's, use Subscribe with a
+Filter. Each client buffers messages it hasn't received yet. By
+default a client whose buffer overflows is removed; the Overflow
+option selects another policy, e.g., Coalesce to keep only the latest
+value of each command, and Client.Dropped counts discarded messages:
+
+  volume := device.Subscribe(integra.CommandFilter("MVL", "AMT"),
+      integra.BufferSize(64), integra.Overflow(integra.Coalesce))
+  message, _ := volume.Receive()
+
+Sent messages are queued and written one at a time at least 50
+milliseconds apart, since Integra devices tend to drop messages sent
+back-to-back. The CommandGap and WaitForEcho options adjust the
+pacing. Messages putting the device in standby are written ahead of
+other waiting messages (see SendPriority), and Device.QueueDepth
+reports the number of waiting messages.
+
+Rapid streams of messages, like those from dragging a volume slider,
+can be coalesced with the CoalesceCommands option. A waiting message
+setting a value, such as MVL20, is replaced by a later message setting
+the same command, and step messages such as MVLUP are rate limited
+(Send returns ErrRateLimited):
+
+  device, _ := integra.Connect(":60128",
+      integra.CoalesceCommands(100*time.Millisecond))
+
+Send returns once a message is written, whether or not the device
+applies it. SendConfirm waits for the device to report the value sent
+and resends the message if it doesn't (the Attempts, ConfirmTimeout
+and RetryBackoff options adjust the retries). Step messages such as
+MVLUP are sent only once:
+
+  err := client.SendConfirm(ctx, &integra.Message{"SLI", "03"})
+  if errors.Is(err, integra.ErrNotConfirmed) {
+      // The device ignored the message.
+  }
+
+Integra devices ignore most commands for several seconds after
+powering on. The PowerOnSettle and PowerOnProbe options hold messages
+following a power on message until the device is ready:
+
+  device, _ := integra.Connect(":60128",
+      integra.PowerOnSettle(3*time.Second), integra.PowerOnProbe("SLI"))
+
+The state starts out empty and is filled in by the messages the
+device sends. The PopulateState option queries the device after
+connecting (by default for every command in the catalog that can be
+queried), and Device.StateReady reports when the replies are in:
+
+  device, _ := integra.Connect(":60128", integra.PopulateState())
+  <-device.StateReady()
+
+Some devices stop sending updates after network standby transitions,
+and a network connection can fail without either end noticing. The
+Poll option queries the device periodically, updating the state with
+its replies and reconnecting if it stops answering:
+
+  device, _ := integra.Connect(":60128",
+      integra.Poll(time.Minute, "PWR", "MVL"))
+
+Client.Snapshot returns the state with the time, sequence number and
+source (pushed by the device or replying to a query) of each value. A
+client that loses track of the state, e.g., after reconnecting to a
+server, can catch up with the changes since a snapshot:
+
+  snapshot := client.Snapshot()
+  // ...
+  changes := client.ChangesSince(snapshot.Seq)
+
+To control a device connected to a serial port instead, use
+ConnectSerial:
+
+  device, _ := integra.ConnectSerial("/dev/ttyUSB0", 9600)
+
+For a serial port exposed over TCP by a bridge such as ser2net, use
+ConnectRawISCP:
+
+  device, _ := integra.ConnectRawISCP("ser2net.local:4001", integra.TerminatorCR)
+
+To find devices on the local network instead of specifying an
+address, use Discover:
+
+  devices, _ := integra.Discover(context.Background())
+  device, _ := integra.Connect(devices[0].Address)
 
 See server/server.go for a working example.
 
@@ -34,12 +162,11 @@ See server/server.go for a working example.
 package integra
 
 import (
+	"context"
 	"errors"
-	"io"
 	"log"
-	"net"
-	"os"
 	"sync"
+	"time"
 )
 
 func init() {
@@ -49,215 +176,474 @@ func init() {
 // state represents the known state of the Integra device.
 type state struct {
 	sync.RWMutex
-	m map[string]string
+	m map[string]StateEntry
+	// seq is the sequence number of the latest change.
+	seq uint64
+	// queries records when the QSTN messages still awaiting a
+	// reply were written for each command, to tell replies from
+	// pushed messages.
+	queries map[string][]time.Time
+	notify  []chan<- Change
+}
+
+var (
+	// ErrNotConnected is returned by Client.Send while the Device
+	// is reconnecting to the Integra device.
+	ErrNotConnected = errors.New("not connected")
+	// ErrClosed is returned by Client methods after the Client or
+	// its Device has been closed.
+	ErrClosed = errors.New("closed")
+)
+
+// conn represents the connection to the Integra device.
+type conn struct {
+	sync.Mutex
+	state     ConnState
+	transport Transport
+	notify    []chan<- ConnEvent
 }
 
+const (
+	// sendQueueSize bounds the number of messages waiting to be
+	// written by writeLoop.
+	sendQueueSize = 64
+	// receiveQueueSize bounds the number of received messages
+	// waiting to be dispatched to clients by dispatchLoop.
+	receiveQueueSize = 256
+)
+
 // Device represents the Integra device, e.g. an A/V receiver.
 type Device struct {
-	conn    net.Conn
-	txbuf   eISCPPacket
-	rxbuf   eISCPPacket
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+	closeOnce  sync.Once
+	loops      sync.WaitGroup
+	dial       DialFunc
+	conn       conn
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	// writeTimeout bounds each write to the transport.
+	writeTimeout time.Duration
+	// skipValidation disables checking sent messages against the
+	// command catalog.
+	skipValidation bool
+	// commandGap is the minimum delay between writes.
+	commandGap time.Duration
+	// echoTimeout bounds the wait for the device to echo each
+	// command written; zero disables waiting.
+	echoTimeout time.Duration
+	// coalesceCommands enables coalescing of waiting messages and
+	// stepInterval rate limits step messages such as MVLUP.
+	coalesceCommands bool
+	stepInterval     time.Duration
+	steps            stepLimiter
+	// powerOnSettle and powerOnProbe configure holding messages
+	// while the device powers on.
+	powerOnSettle time.Duration
+	powerOnProbe  string
+	// populateOnConnect enables querying the populate commands
+	// (or the whole catalog if empty) after connecting; stateReady
+	// is closed when done.
+	populateOnConnect bool
+	populate          []string
+	stateReady        chan struct{}
+	// pollInterval enables pollLoop, which queries pollCommands.
+	pollInterval time.Duration
+	pollCommands []string
+	// queued counts the messages waiting to be written. It is
+	// accessed atomically.
+	queued  int64
 	state   state
+	waiters waiters
 	clients map[*Client]bool
 	add     chan *Client
 	remove  chan *Client
 	send    chan *sendRequest
 	receive chan *Message
-	exit    chan int
 }
 
-// Connect establishes a connection to the Integra device and returns
-// a new Device. Only one network peer (i.e., Device) may be used to
-// communicate with the Integra device at a time.
-func Connect(address string) (*Device, error) {
-	conn, err := net.Dial("tcp", address)
+// Connect establishes a connection to the Integra device at the
+// given TCP address using eISCP and returns a new Device. Only one
+// network peer (i.e., Device) may be used to communicate with the
+// Integra device at a time.
+func Connect(address string, opts ...Option) (*Device, error) {
+	return ConnectContext(context.Background(), address, opts...)
+}
+
+// ConnectContext is like Connect but gives up establishing the
+// initial connection when ctx is done.
+func ConnectContext(ctx context.Context, address string, opts ...Option) (*Device, error) {
+	return ConnectTransportContext(ctx, func(ctx context.Context) (Transport, error) {
+		return DialEISCP(ctx, address)
+	}, opts...)
+}
+
+// ConnectSerial opens the serial port at path and returns a new Device
+// that communicates with the Integra device connected to it using
+// ISCP at the given baud rate (9600 for most devices).
+func ConnectSerial(path string, baud int, opts ...Option) (*Device, error) {
+	return ConnectTransport(func(ctx context.Context) (Transport, error) {
+		return OpenSerial(path, baud)
+	}, opts...)
+}
+
+// ConnectRawISCP establishes a connection to the given TCP address,
+// such as a ser2net bridge exposing the Integra device's RS-232 port,
+// and returns a new Device that communicates using line-framed ISCP.
+// Each message sent is terminated with terminator (e.g.,
+// TerminatorCR).
+func ConnectRawISCP(address, terminator string, opts ...Option) (*Device, error) {
+	return ConnectTransport(func(ctx context.Context) (Transport, error) {
+		return DialRawISCP(ctx, address, terminator)
+	}, opts...)
+}
+
+// ConnectTransport opens a Transport with dial and returns a new
+// Device that communicates with the Integra device over it. If the
+// connection is lost, the Device calls dial again to reconnect.
+func ConnectTransport(dial DialFunc, opts ...Option) (*Device, error) {
+	return ConnectTransportContext(context.Background(), dial, opts...)
+}
+
+// ConnectTransportContext is like ConnectTransport but passes ctx to
+// dial when establishing the initial connection. Reconnect attempts
+// are not affected by ctx.
+func ConnectTransportContext(ctx context.Context, dial DialFunc, opts ...Option) (*Device, error) {
+	transport, err := dial(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Note: since there can only be a single TCP connection to
-	// the Integra device at a time, it's acceptable to reuse
-	// transmit and receive buffers instead of creating new ones
-	// for each message sent and received.
+	deviceCtx, cancel := context.WithCancel(context.Background())
 	device := &Device{
-		conn:  conn,
-		txbuf: newEISCPPacket(),
-		rxbuf: make(eISCPPacket, packetSize),
+		ctx:          deviceCtx,
+		cancel:       cancel,
+		done:         make(chan struct{}),
+		dial:         dial,
+		conn:         conn{state: Connected, transport: transport},
+		minBackoff:   defaultMinBackoff,
+		maxBackoff:   defaultMaxBackoff,
+		writeTimeout: defaultWriteTimeout,
+		commandGap:   defaultCommandGap,
 		// Concurrent access to state map is managed with a
 		// RWMutex.
-		state: state{m: make(map[string]string)},
+		state: state{
+			m:       make(map[string]StateEntry),
+			queries: make(map[string][]time.Time),
+		},
+		waiters: waiters{m: make(map[string]map[chan *Message]bool)},
 		// clients map is not thread safe and must not be
-		// accessed outside the mainLoop goroutine.
-		clients: make(map[*Client]bool),
-		add:     make(chan *Client),
-		remove:  make(chan *Client),
-		send:    make(chan *sendRequest),
-		receive: make(chan *Message),
-		exit:    make(chan int)}
+		// accessed outside the dispatchLoop goroutine.
+		clients:    make(map[*Client]bool),
+		add:        make(chan *Client),
+		remove:     make(chan *Client),
+		send:       make(chan *sendRequest, sendQueueSize),
+		receive:    make(chan *Message, receiveQueueSize),
+		stateReady: make(chan struct{})}
+	for _, opt := range opts {
+		opt(device)
+	}
 
-	go device.receiveLoop()
-	go device.mainLoop()
+	// Reading, writing and dispatching to clients run in separate
+	// goroutines connected by bounded queues so that a slow write
+	// doesn't hold up receiving and vice versa.
+	device.loops.Add(3)
+	go device.receiveLoop(transport)
+	go device.writeLoop()
+	go device.dispatchLoop()
+	if device.pollInterval > 0 {
+		device.loops.Add(1)
+		go device.pollLoop()
+	}
+
+	if device.populateOnConnect {
+		go device.populateState()
+	} else {
+		close(device.stateReady)
+	}
 
 	return device, nil
 }
 
+// Close closes the connection to the Integra device and stops the
+// Device. All clients are closed, and subsequent calls to Client.Send
+// return ErrClosed. Close waits for the Device's goroutines to exit.
+func (d *Device) Close() error {
+	var err error
+	d.closeOnce.Do(func() {
+		log.Println("Closing device")
+		// Take the transport in the same critical section that
+		// marks the Device closed so that reconnect can't
+		// install a new one that is never closed.
+		transport, _ := d.setConnState(Closed, nil, ErrClosed)
+		d.cancel()
+		close(d.done)
+		d.closeNotify()
+		if transport != nil {
+			err = transport.Close()
+		}
+	})
+	d.loops.Wait()
+	return err
+}
+
+// Done returns a channel that is closed when the Device is closed.
+func (d *Device) Done() <-chan struct{} {
+	return d.done
+}
+
+// Err returns nil if the Device is running and ErrClosed once it has
+// been closed.
+func (d *Device) Err() error {
+	select {
+	case <-d.done:
+		return ErrClosed
+	default:
+		return nil
+	}
+}
+
 func (d *Device) removeClient(client *Client, explicit bool) {
 	// Check the map first to make it safe to call this method for
 	// a client that was previously removed via the other removal
 	// path (explicit/implicit). This can happen, for example, if
-	// a client isn't set up to receive. The extra tolerance here
-	// keeps the Client interface simple.
+	// a client's buffer overflows before it is closed. The extra
+	// tolerance here keeps the Client interface simple.
 	if !d.clients[client] {
 		return
 	}
 	if !explicit {
-		// We didn't get here via the client's Close method. The
-		// likely case is that a message was received from the
-		// device and the client isn't set up to receive. This
-		// is a valid case since some clients only need to send
-		// and check state, so remove the client and move on.
+		// We didn't get here via the client's Close method:
+		// the client's buffer overflowed and its policy is
+		// Disconnect.
 		log.Printf("Client %p unable to receive\n", client)
 	}
 	log.Printf("Removing client %p\n", client)
 	delete(d.clients, client)
-	// Close channel to unblock client's Receive call (and
+	// Close the queue to unblock client's Receive call (and
 	// allow the goroutine that called it to shut down).
-	close(client.receive)
+	client.queue.close()
 }
 
-// mainLoop runs in its own goroutine and is in charge of adding and
-// removing clients and routing messages between clients and the
-// device.
-func (d *Device) mainLoop() {
+// dispatchLoop runs in its own goroutine and is in charge of adding
+// and removing clients and dispatching received messages to them.
+// Dispatching never blocks on a client; see OverflowPolicy.
+func (d *Device) dispatchLoop() {
+	defer d.loops.Done()
 	for {
 		select {
+		case <-d.done:
+			for client := range d.clients {
+				d.removeClient(client, true)
+			}
+			return
 		case client := <-d.add:
 			log.Printf("Adding client %p\n", client)
 			d.clients[client] = true
 		case client := <-d.remove:
 			d.removeClient(client, true)
-		case request := <-d.send:
-			err := d.txbuf.init(request.message.String())
-			if err != nil {
-				log.Println("init failed:", err)
-				request.client.err <- err
-				continue
-			}
-			n, err := d.conn.Write(d.txbuf)
-			if err != nil {
-				log.Println("Write failed:", err)
-				request.client.err <- err
-				continue
-			}
-			log.Printf("Sent message %v (%v bytes)\n", request.message, n)
-			request.client.err <- err
 		case message := <-d.receive:
 			for client := range d.clients {
-				select {
-				case client.receive <- message:
-				default:
+				if client.filter != nil && !client.filter(message) {
+					continue
+				}
+				if !client.queue.push(message) {
 					d.removeClient(client, false)
 				}
 			}
 			log.Printf("Broadcast %v to %v clients\n", message, len(d.clients))
-		case code := <-d.exit:
-			os.Exit(code)
 		}
 	}
 }
 
+// write writes the message in request to the transport. A failed
+// write leaves the stream in an unknown state, so the transport is
+// closed, which causes receiveLoop to reconnect.
+func (d *Device) write(request *sendRequest) error {
+	if d.Err() != nil {
+		return ErrClosed
+	}
+	if err := request.ctx.Err(); err != nil {
+		// The sender gave up before the message was written.
+		return err
+	}
+	transport := d.transport()
+	if transport == nil {
+		return ErrNotConnected
+	}
+	if t, ok := transport.(writeDeadliner); ok && d.writeTimeout > 0 {
+		_ = t.SetWriteDeadline(time.Now().Add(d.writeTimeout))
+	}
+	query := request.message.Parameter == "QSTN"
+	queriedAt := time.Now()
+	if query {
+		// Record the query before writing so that a prompt
+		// reply is attributed to it.
+		d.state.queried(request.message.Command, queriedAt)
+	}
+	if err := transport.WriteMessage(request.message); err != nil {
+		log.Println("WriteMessage failed:", err)
+		if query {
+			d.state.unqueried(request.message.Command, queriedAt)
+		}
+		_ = transport.Close()
+		return err
+	}
+	log.Printf("Sent message %v\n", request.message)
+	return nil
+}
+
 // receiveLoop runs in its own goroutine and blocks while waiting for
-// new messages to arrive from the device. Received messages are
-// forwarded over the device's receive channel.
-func (d *Device) receiveLoop() {
+// new messages to arrive from the device over transport. Received
+// messages are forwarded over the device's receive channel. If the
+// connection is lost, receiveLoop reconnects and carries on with the
+// new transport.
+func (d *Device) receiveLoop(transport Transport) {
+	defer d.loops.Done()
 	for {
-		n, err := d.conn.Read(d.rxbuf)
+		message, err := transport.ReadMessage()
 		if err != nil {
-			if err == io.EOF {
-				log.Println("EOF read from device; shutting down")
-				d.exit <- 1
+			_ = transport.Close()
+			if d.Err() != nil {
+				return
+			}
+			log.Println("ReadMessage failed:", err)
+			if _, ok := d.setConnState(Disconnected, nil, err); !ok {
+				return
+			}
+			if transport = d.reconnect(); transport == nil {
+				return
 			}
-			log.Println("Read failed:", err)
-			continue
-		}
-		if err := d.rxbuf.check(endOfPacketRx); err != nil {
-			log.Printf("Received bad packet (%v):%v", err, d.rxbuf.debugString())
-			continue
-		}
-		message, err := d.rxbuf.message()
-		if err != nil {
-			log.Println("message failed:", err)
 			continue
 		}
-		log.Printf("Received %v (%v bytes)\n", message, n)
+		log.Printf("Received %v\n", message)
 
-		d.state.Lock()
-		d.state.m[message.Command] = message.Parameter
-		d.state.Unlock()
+		d.updateState(message)
+		d.resolve(message)
 
-		d.receive <- message
+		select {
+		case d.receive <- message:
+		case <-d.done:
+			return
+		}
 	}
 }
 
-// sendRequest is sent over device's send channel with a message from
-// a Client and allows an error to be returned to the sender over its
-// err channel.
+// sendRequest is sent over device's send channel with a message and
+// allows an error to be returned to the sender over its err channel.
+// The err channel is buffered so that writeLoop never blocks on a
+// sender that has given up.
 type sendRequest struct {
-	message *Message
-	client  *Client
+	ctx      context.Context
+	message  *Message
+	priority Priority
+	// seq orders requests with the same priority.
+	seq uint64
+	// ungated requests are written while the device is powering
+	// on. See PowerOnSettle.
+	ungated bool
+	err     chan error
 }
 
 // A Client is an Integra device network client.
 type Client struct {
-	device  *Device
-	receive chan *Message
-	err     chan error
+	device *Device
+	// queue buffers received messages; it is nil for send-only
+	// clients.
+	queue *queue
+	// filter selects the messages sent to the client; nil
+	// selects all messages.
+	filter Filter
 }
 
 // NewClient returns a new Integra device client, ready to send and
-// receive messages.
+// receive all messages. The client remains attached to the Device
+// when the Device reconnects to the Integra device. It is equivalent
+// to Subscribe(nil).
 func (d *Device) NewClient() *Client {
-	c := &Client{d, make(chan *Message), make(chan error)}
-	d.add <- c
-	return c
+	return d.Subscribe(nil)
 }
 
 // NewSendOnlyClient returns a new Integra device client, ready to
 // send messages. Client cannot receive messages.
 func (d *Device) NewSendOnlyClient() *Client {
-	return &Client{d, nil, make(chan error)}
+	return &Client{device: d}
 }
 
-// Send sends the given message to the Integra device.
+// Send sends the given message to the Integra device. ErrNotConnected
+// is returned if the Device is reconnecting and ErrClosed if it has
+// been closed. Messages whose parameter isn't accepted by the
+// command catalog are not sent; an error wrapping ErrInvalidParameter
+// is returned instead.
 func (c *Client) Send(m *Message) error {
-	c.device.send <- &sendRequest{m, c}
-	return <-c.err
+	return c.SendContext(context.Background(), m)
+}
+
+// SendContext is like Send but returns ctx.Err() if ctx is done before
+// the message has been written. A message whose context is done
+// before its turn comes is not written.
+func (c *Client) SendContext(ctx context.Context, m *Message) error {
+	return c.SendPriority(ctx, m, priorityOf(m))
+}
+
+// SendPriority is like SendContext but queues the message with the
+// given priority, so that it is written before any waiting messages
+// with lower priority.
+func (c *Client) SendPriority(ctx context.Context, m *Message, priority Priority) error {
+	err := c.send(ctx, m, priority)
+	if err == ErrSuperseded {
+		// The newer value is written in its place.
+		return nil
+	}
+	return err
+}
+
+// send validates m and queues it to be written, returning
+// ErrSuperseded if CoalesceCommands replaced it.
+func (c *Client) send(ctx context.Context, m *Message, priority Priority) error {
+	if !c.device.skipValidation {
+		if err := ValidateMessage(m); err != nil {
+			return err
+		}
+	}
+	return c.device.sendMessage(ctx, m, priority)
 }
 
 // Receive blocks until a new message is received from the Integra
-// device and returns the message.
+// device and returns the message. ErrClosed is returned once the
+// client or its Device has been closed.
 func (c *Client) Receive() (*Message, error) {
-	m, ok := <-c.receive
-	if !ok {
-		return nil, errors.New("channel closed")
+	return c.ReceiveContext(context.Background())
+}
+
+// ReceiveContext is like Receive but returns ctx.Err() if ctx is done
+// before a message is received.
+func (c *Client) ReceiveContext(ctx context.Context) (*Message, error) {
+	if c.queue == nil {
+		// Send-only clients never receive.
+		<-ctx.Done()
+		return nil, ctx.Err()
 	}
-	return m, nil
+	return c.queue.pop(ctx)
 }
 
 // State returns a map representing the known state of the Integra
 // device. Keys are ISCP message commands that map to ISCP parameter
 // values. Each pair reflects the most recently received value for the
-// key. Example key:value pair: PWR:01.
+// key. Example key:value pair: PWR:01. Commands for which the device
+// last replied "N/A" are omitted.
 //
-// To populate the state with a desired command:paremeter pair, send
-// the corresponding QSTN message (e.g., PWRQSTN) prior to calling
-// this method. Note that it may be necessary to sleep for ~50ms in
-// between.
+// To populate the state with a desired command:parameter pair, call
+// Query (e.g., with PWR) prior to calling this method. See DeviceState
+// for the state decoded into typed values.
 func (c *Client) State() map[string]string {
 	state := make(map[string]string)
 	c.device.state.RLock()
-	for k, v := range c.device.state.m {
-		state[k] = v
+	for k, entry := range c.device.state.m {
+		if entry.Parameter != "" {
+			state[k] = entry.Parameter
+		}
 	}
 	c.device.state.RUnlock()
 	return state
@@ -265,5 +651,8 @@ func (c *Client) State() map[string]string {
 
 // Close removes client from device. Client can no longer receive messages.
 func (c *Client) Close() {
-	c.device.remove <- c
+	select {
+	case c.device.remove <- c:
+	case <-c.device.done:
+	}
 }
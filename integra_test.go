@@ -0,0 +1,391 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTransport is an in-memory Transport. Tests play the part of the
+// Integra device by sending messages on in and reading the messages
+// the Device wrote from out.
+type fakeTransport struct {
+	in     chan *Message
+	out    chan *Message
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeTransport() *fakeTransport {
+	return &fakeTransport{
+		in:     make(chan *Message),
+		out:    make(chan *Message, 100),
+		closed: make(chan struct{}),
+	}
+}
+
+func (t *fakeTransport) ReadMessage() (*Message, error) {
+	select {
+	case m := <-t.in:
+		return m, nil
+	case <-t.closed:
+		return nil, io.EOF
+	}
+}
+
+func (t *fakeTransport) WriteMessage(m *Message) error {
+	select {
+	case <-t.closed:
+		return errors.New("transport closed")
+	default:
+	}
+	select {
+	case t.out <- m:
+		return nil
+	case <-t.closed:
+		return errors.New("transport closed")
+	}
+}
+
+func (t *fakeTransport) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
+
+// expectWritten fails the test unless the next message written to the
+// transport is expected.
+func (t *fakeTransport) expectWritten(tb testing.TB, expected string) {
+	tb.Helper()
+	select {
+	case m := <-t.out:
+		if m.String() != expected {
+			tb.Errorf("wrote %v, expected %v", m, expected)
+		}
+	case <-time.After(time.Second):
+		tb.Fatalf("timed out waiting for %v to be written", expected)
+	}
+}
+
+// serve plays the part of an Integra device with the given state
+// until the transport is closed: QSTN messages are answered from the
+// state ("N/A" for unknown commands), while other messages update the
+// state and are echoed back.
+func (t *fakeTransport) serve(state map[string]string) {
+	var mu sync.Mutex
+	for {
+		select {
+		case m := <-t.out:
+			mu.Lock()
+			reply := &Message{m.Command, m.Parameter}
+			if m.Parameter == "QSTN" {
+				if p, ok := state[m.Command]; ok {
+					reply.Parameter = p
+				} else {
+					reply.Parameter = "N/A"
+				}
+			} else {
+				state[m.Command] = m.Parameter
+			}
+			mu.Unlock()
+			go func() {
+				select {
+				case t.in <- reply:
+				case <-t.closed:
+				}
+			}()
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// fakeDialer returns a DialFunc that hands out the given transports in
+// order.
+func fakeDialer(transports ...*fakeTransport) DialFunc {
+	var mu sync.Mutex
+	return func(ctx context.Context) (Transport, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(transports) == 0 {
+			return nil, errors.New("connection refused")
+		}
+		t := transports[0]
+		transports = transports[1:]
+		if t == nil {
+			return nil, errors.New("connection refused")
+		}
+		return t, nil
+	}
+}
+
+// collect receives messages for client in a new goroutine and
+// forwards them over the returned channel, which is closed when the
+// client is closed.
+func collect(client *Client) <-chan *Message {
+	messages := make(chan *Message, 100)
+	go func() {
+		defer close(messages)
+		for {
+			m, err := client.Receive()
+			if err != nil {
+				return
+			}
+			messages <- m
+		}
+	}()
+	return messages
+}
+
+// expectReceived fails the test unless the next message on messages
+// is expected.
+func expectReceived(tb testing.TB, messages <-chan *Message, expected string) {
+	tb.Helper()
+	select {
+	case m := <-messages:
+		if m == nil || m.String() != expected {
+			tb.Errorf("received %v, expected %v", m, expected)
+		}
+	case <-time.After(time.Second):
+		tb.Fatalf("timed out waiting to receive %v", expected)
+	}
+}
+
+func TestSendReceive(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	client := device.NewClient()
+	defer client.Close()
+	messages := collect(client)
+
+	if err := client.Send(&Message{"PWR", "01"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	transport.expectWritten(t, "PWR01")
+	transport.in <- &Message{"PWR", "01"}
+	expectReceived(t, messages, "PWR01")
+	if state := client.State(); state["PWR"] != "01" {
+		t.Errorf("state %v did not contain PWR:01", state)
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	first, second := newFakeTransport(), newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(first, nil, second),
+		ReconnectBackoff(time.Millisecond, 2*time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	events := make(chan ConnEvent, 10)
+	device.NotifyConnState(events)
+	client := device.NewClient()
+	defer client.Close()
+	messages := collect(client)
+
+	first.in <- &Message{"PWR", "01"}
+	expectReceived(t, messages, "PWR01")
+
+	// Simulate the device going away.
+	_ = first.Close()
+
+	// State is refreshed after reconnecting.
+	second.expectWritten(t, "PWRQSTN")
+	second.in <- &Message{"PWR", "00"}
+	expectReceived(t, messages, "PWR00")
+	if state := device.ConnState(); state != Connected {
+		t.Errorf("state %v, expected %v", state, Connected)
+	}
+
+	expected := []ConnState{Disconnected, Connecting, Disconnected, Connecting, Connected}
+	for _, e := range expected {
+		event := <-events
+		if event.State != e {
+			t.Errorf("event %v, expected %v", event, e)
+		}
+	}
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	tests := []struct {
+		min, max                 time.Duration
+		expectedMin, expectedMax time.Duration
+	}{
+		{time.Millisecond, time.Second, time.Millisecond, time.Second},
+		{0, time.Minute, defaultMinBackoff, time.Minute},
+		{-time.Second, 0, defaultMinBackoff, defaultMinBackoff},
+		{time.Second, time.Millisecond, time.Second, time.Second},
+	}
+	for _, tt := range tests {
+		d := &Device{}
+		ReconnectBackoff(tt.min, tt.max)(d)
+		if d.minBackoff != tt.expectedMin || d.maxBackoff != tt.expectedMax {
+			t.Errorf("ReconnectBackoff(%v, %v) set %v, %v, expected %v, %v",
+				tt.min, tt.max, d.minBackoff, d.maxBackoff, tt.expectedMin, tt.expectedMax)
+		}
+	}
+}
+
+func TestClose(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	client := device.NewClient()
+	messages := collect(client)
+
+	if err := device.Err(); err != nil {
+		t.Errorf("Err returned %v before Close", err)
+	}
+	if err := device.Close(); err != nil {
+		t.Error("Close failed:", err)
+	}
+	select {
+	case <-device.Done():
+	default:
+		t.Error("Done channel not closed")
+	}
+	if err := device.Err(); err != ErrClosed {
+		t.Errorf("Err returned %v, expected %v", err, ErrClosed)
+	}
+	select {
+	case <-transport.closed:
+	default:
+		t.Error("transport not closed")
+	}
+	if _, ok := <-messages; ok {
+		t.Error("client received message after Close")
+	}
+	if err := client.Send(&Message{"PWR", "01"}); err != ErrClosed {
+		t.Errorf("Send returned %v, expected %v", err, ErrClosed)
+	}
+	if _, err := device.NewClient().Receive(); err != ErrClosed {
+		t.Errorf("Receive returned %v, expected %v", err, ErrClosed)
+	}
+	client.Close()
+	if state := device.ConnState(); state != Closed {
+		t.Errorf("state %v, expected %v", state, Closed)
+	}
+}
+
+func TestCloseWhileReconnecting(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport),
+		ReconnectBackoff(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	events := make(chan ConnEvent, 100)
+	device.NotifyConnState(events)
+	_ = transport.Close()
+	for event := range events {
+		if event.State == Connecting {
+			break
+		}
+	}
+	if err := device.Close(); err != nil {
+		t.Error("Close failed:", err)
+	}
+	if state := device.ConnState(); state != Closed {
+		t.Errorf("state %v, expected %v", state, Closed)
+	}
+}
+
+func TestCloseDuringDial(t *testing.T) {
+	first, second := newFakeTransport(), newFakeTransport()
+	dialing, release := make(chan bool), make(chan bool)
+	dials := 0
+	dial := func(ctx context.Context) (Transport, error) {
+		dials++
+		if dials == 1 {
+			return first, nil
+		}
+		dialing <- true
+		<-release
+		return second, nil
+	}
+	device, err := ConnectTransport(dial, ReconnectBackoff(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	_ = first.Close()
+	<-dialing
+	closed := make(chan error)
+	go func() { closed <- device.Close() }()
+	<-device.Done()
+	// The dial completes after Close has taken the transport.
+	release <- true
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not return")
+	}
+	select {
+	case <-second.closed:
+	default:
+		t.Error("transport dialed during Close was not closed")
+	}
+}
+
+func TestSendContext(t *testing.T) {
+	transport := newFakeTransport()
+	// Writes block until the test reads them.
+	transport.out = make(chan *Message)
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewSendOnlyClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.SendContext(ctx, &Message{"PWR", "01"}); err != context.DeadlineExceeded {
+		t.Errorf("SendContext returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	transport.expectWritten(t, "PWR01")
+
+	// A message whose context is done before its turn comes is
+	// never written.
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.SendContext(canceled, &Message{"PWR", "00"}); err != context.Canceled {
+		t.Errorf("SendContext returned %v, expected %v", err, context.Canceled)
+	}
+	go func() { _ = client.Send(&Message{"MVL", "20"}) }()
+	transport.expectWritten(t, "MVL20")
+}
+
+func TestReceiveContext(t *testing.T) {
+	device, err := ConnectTransport(fakeDialer(newFakeTransport()))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.ReceiveContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("ReceiveContext returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+// ISCP: Integra Serial Control Protocol (RS-232)
+//
+// ISCP protocol notes:
+//
+// - Messages are not wrapped in a packet; each one is a line made up
+//   of the start character '!', the unit type character ('1' for
+//   receiver) and the message, e.g. "!1PWR01".
+//
+// - Messages sent to the device are terminated with CR, LF or CR LF.
+//   Messages received from the device are terminated with 0x1a
+//   (EOF), which may be followed by CR and/or LF.
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"time"
+)
+
+// Terminators for ISCP messages sent to the device. Which ones a
+// device accepts depends on its firmware; CR is the most widely
+// supported. Messages received from the device may use any of them.
+const (
+	TerminatorCR   = "\r"
+	TerminatorLF   = "\n"
+	TerminatorCRLF = "\r\n"
+	TerminatorEOF  = "\x1a"
+)
+
+// iscpTransport is a Transport that speaks line-framed ISCP over a
+// byte stream such as a serial port.
+type iscpTransport struct {
+	rwc io.ReadWriteCloser
+	r   *bufio.Reader
+	eol string
+}
+
+// newISCPTransport returns a Transport that speaks ISCP over rwc and
+// terminates each message sent with eol.
+func newISCPTransport(rwc io.ReadWriteCloser, eol string) *iscpTransport {
+	return &iscpTransport{rwc, bufio.NewReader(rwc), eol}
+}
+
+// DialRawISCP connects to the given TCP address, such as a ser2net
+// bridge exposing a device's RS-232 port, and returns a Transport that
+// speaks line-framed ISCP over the connection. Each message sent is
+// terminated with terminator (e.g., TerminatorCR).
+func DialRawISCP(ctx context.Context, address, terminator string) (Transport, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return newISCPTransport(conn, terminator), nil
+}
+
+// isEndOfLine reports whether b terminates an ISCP message.
+func isEndOfLine(b byte) bool {
+	return b == endOfPacketRx || b == '\r' || b == '\n'
+}
+
+// readLine returns the next non-empty line from the stream, without
+// its terminator.
+func (t *iscpTransport) readLine() ([]byte, error) {
+	var line []byte
+	for {
+		b, err := t.r.ReadByte()
+		if err != nil {
+			if len(line) > 0 {
+				err = unexpectedEOF(err)
+			}
+			return nil, err
+		}
+		if isEndOfLine(b) {
+			if len(line) > 0 {
+				return line, nil
+			}
+			continue
+		}
+		if len(line) >= maxDataSize {
+			log.Printf("Discarding line longer than %v bytes", maxDataSize)
+			line = line[:0]
+		}
+		line = append(line, b)
+	}
+}
+
+func (t *iscpTransport) ReadMessage() (*Message, error) {
+	for {
+		line, err := t.readLine()
+		if err != nil {
+			return nil, err
+		}
+		// Skip any noise preceding the start character.
+		start := bytes.IndexByte(line, '!')
+		if start < 0 || len(line) < start+dataStartSize || line[start+1] != '1' {
+			log.Printf("Received bad line %q", line)
+			continue
+		}
+		message, err := NewMessage(line[start+dataStartSize:])
+		if err != nil {
+			log.Println("NewMessage failed:", err)
+			continue
+		}
+		return message, nil
+	}
+}
+
+func (t *iscpTransport) WriteMessage(m *Message) error {
+	_, err := fmt.Fprintf(t.rwc, "!1%v%v", m, t.eol)
+	return err
+}
+
+func (t *iscpTransport) Close() error {
+	return t.rwc.Close()
+}
+
+// SetWriteDeadline sets the write deadline of the underlying stream if
+// it supports deadlines (e.g., net.Conn or a pollable *os.File).
+func (t *iscpTransport) SetWriteDeadline(deadline time.Time) error {
+	if d, ok := t.rwc.(writeDeadliner); ok {
+		return d.SetWriteDeadline(deadline)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+)
+
+// nopCloser adds a no-op Close method to an io.ReadWriter.
+type nopCloser struct {
+	io.ReadWriter
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestISCPTransportReadMessage(t *testing.T) {
+	input := "!1PWR01\x1a" +
+		"!1MVL2A\x1a\r\n" +
+		"\r\n" +
+		"noise!1AMT00\r" +
+		"bad\n" +
+		"!1SLI03\n"
+	transport := newISCPTransport(nopCloser{struct {
+		io.Reader
+		io.Writer
+	}{strings.NewReader(input), ioutil.Discard}}, "\r")
+	expected := []string{"PWR01", "MVL2A", "AMT00", "SLI03"}
+	for _, e := range expected {
+		m, err := transport.ReadMessage()
+		if err != nil {
+			t.Fatal("ReadMessage failed:", err)
+		}
+		if m.String() != e {
+			t.Errorf("%v did not match expected %v", m, e)
+		}
+	}
+	if _, err := transport.ReadMessage(); err != io.EOF {
+		t.Errorf("expected %v but got %v", io.EOF, err)
+	}
+}
+
+func TestISCPTransportWriteMessage(t *testing.T) {
+	tests := []string{TerminatorCR, TerminatorLF, TerminatorCRLF, TerminatorEOF}
+	for _, terminator := range tests {
+		var buffer bytes.Buffer
+		transport := newISCPTransport(nopCloser{&buffer}, terminator)
+		if err := transport.WriteMessage(&Message{"PWR", "01"}); err != nil {
+			t.Fatal("WriteMessage failed:", err)
+		}
+		if result := buffer.String(); result != "!1PWR01"+terminator {
+			t.Errorf("%q did not match expected %q", result, "!1PWR01"+terminator)
+		}
+	}
+}
+
+func TestDialRawISCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("Listen failed:", err)
+	}
+	defer l.Close()
+	received := make(chan string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte("!1PWR01\x1a\r\n"))
+		line, _ := bufio.NewReader(conn).ReadString('\n')
+		received <- line
+	}()
+
+	transport, err := DialRawISCP(context.Background(), l.Addr().String(), TerminatorCRLF)
+	if err != nil {
+		t.Fatal("DialRawISCP failed:", err)
+	}
+	defer transport.Close()
+	m, err := transport.ReadMessage()
+	if err != nil {
+		t.Fatal("ReadMessage failed:", err)
+	}
+	if m.String() != "PWR01" {
+		t.Errorf("%v did not match expected PWR01", m)
+	}
+	if err := transport.WriteMessage(&Message{"PWR", "00"}); err != nil {
+		t.Fatal("WriteMessage failed:", err)
+	}
+	if line := <-received; line != "!1PWR00\r\n" {
+		t.Errorf("%q did not match expected %q", line, "!1PWR00\r\n")
+	}
+}
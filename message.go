@@ -19,81 +19,105 @@ package integra
 //
 // eISCP protocol notes:
 //
-// - The data segment of a packet is fixed at 16 bytes (maxDataSize)
-//   while the size of the data in the segment is variable and
-//   determined by the byte at dataSizeIndex.
+// - A packet is made up of a fixed 16 byte header followed by a
+//   variable length data segment. The header holds the header size
+//   and the data segment size, each as a 4 byte big-endian integer
+//   starting at headerSizeIndex and dataSizeIndex respectively.
 //
 // - The first two bytes in the data segment of a packet are the start
 //   character '!' and the unit type character ('1' for receiver).
 //
 // - The end of a packet received from the Integra device is marked
-//   with 0x1a, while the end of a packet sent to the device is marked
-//   with 0x0a.
+//   with 0x1a (optionally followed by CR and/or LF), while the end of
+//   a packet sent to the device is marked with 0x0a.
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 )
 
 const (
-	headerSize      byte = 16
-	maxDataSize     byte = 16
-	packetSize      byte = headerSize + maxDataSize
-	headerSizeIndex byte = 7
-	endOfPacketSize byte = 1
-	dataStartSize   byte = 2 // data start: "!1"
-	dataOverhead    byte = dataStartSize + endOfPacketSize
-	dataSizeIndex   byte = 11
-	maxMessageSize  byte = maxDataSize - dataOverhead
-	messageOffset   byte = headerSize + dataStartSize
-	endOfPacketTx   byte = 0x0a
-	endOfPacketRx   byte = 0x1a
+	headerSize      = 16
+	headerSizeIndex = 4
+	dataSizeIndex   = 8
+	versionIndex    = 12
+	iscpVersion     = 0x01
+	maxDataSize     = 1 << 20 // sanity limit; NRI and NJA data can be large
+	endOfPacketSize = 1
+	dataStartSize   = 2 // data start: "!1"
+	dataOverhead    = dataStartSize + endOfPacketSize
+	messageOffset   = headerSize + dataStartSize
+	endOfPacketTx   = 0x0a
+	endOfPacketRx   = 0x1a
 )
 
+// iscpMagic is the first 4 bytes of every eISCP packet.
+var iscpMagic = []byte("ISCP")
+
 // eISCPPacket contains the bytes that make up a message sent to or
 // received from an Integra device over Ethernet.
 type eISCPPacket []byte
 
-func newEISCPPacket() eISCPPacket {
-	return eISCPPacket{
-		0x49, 0x53, 0x43, 0x50, // I S C P
-		0x00, 0x00, 0x00, 0x10, //      16 - header size
-		0x00, 0x00, 0x00, 0x00, //       0 - data size
-		0x01, 0x00, 0x00, 0x00, // 1       - ISCP version
-
-		0x21, 0x31, 0x00, 0x00, // ! 1     - data start
-		0x00, 0x00, 0x00, 0x00, // }
-		0x00, 0x00, 0x00, 0x00, // } Empty message
-		0x00, 0x00, 0x00, 0x00, // }
-	}
+// newEISCPPacket returns an outbound eISCPPacket containing the given
+// message. The packet is sized to fit the message exactly.
+func newEISCPPacket(message string) eISCPPacket {
+	dataSize := len(message) + dataOverhead
+	p := make(eISCPPacket, headerSize+dataSize)
+	copy(p, iscpMagic)
+	binary.BigEndian.PutUint32(p[headerSizeIndex:], headerSize)
+	binary.BigEndian.PutUint32(p[dataSizeIndex:], uint32(dataSize))
+	p[versionIndex] = iscpVersion
+	p[headerSize] = '!'
+	p[headerSize+1] = '1'
+	copy(p[messageOffset:], message)
+	p[len(p)-1] = endOfPacketTx
+	return p
 }
 
-// init initializes an outbound eISCPPacket that was created with
-// newEISCPPacket with the given message.
-func (p eISCPPacket) init(message string) error {
-	if len(message) > int(maxMessageSize) {
-		return fmt.Errorf("Message '%v' too long", message)
-	}
-	p[dataSizeIndex] = byte(len(message)) + dataOverhead
-	index := messageOffset
-	for i := range message {
-		p[index] = message[i]
-		index++
-	}
-	p[index] = endOfPacketTx
-	for i := index + 1; i < packetSize; i++ {
-		p[i] = 0x00
+// dataSize returns the size of the data segment recorded in header.
+func dataSize(header []byte) int {
+	return int(binary.BigEndian.Uint32(header[dataSizeIndex:]))
+}
+
+// checkHeader performs an integrity check on the 16 byte header of a
+// packet.
+func checkHeader(header []byte) error {
+	switch {
+	case len(header) < headerSize:
+		return fmt.Errorf("packet size %v is less than header size %v",
+			len(header), headerSize)
+	case !bytes.Equal(header[:len(iscpMagic)], iscpMagic):
+		return errors.New("first 4 header bytes do not match ISCP")
+	case binary.BigEndian.Uint32(header[headerSizeIndex:]) != headerSize:
+		return fmt.Errorf("header size %#02x is not expected size %#02x",
+			binary.BigEndian.Uint32(header[headerSizeIndex:]), headerSize)
+	case dataSize(header) < dataOverhead:
+		return fmt.Errorf("data size %v less than min size %v",
+			dataSize(header), dataOverhead)
+	case dataSize(header) > maxDataSize:
+		return fmt.Errorf("data size %v greater than max size %v",
+			dataSize(header), maxDataSize)
 	}
 	return nil
 }
 
+// data returns the data segment of the packet, excluding the
+// end-of-packet marker and any trailing CR, LF or NUL bytes.
+func (p eISCPPacket) data() []byte {
+	return bytes.TrimRight(p[headerSize:], "\x00\r\n\x1a")
+}
+
 // message extracts the ISCP message from packet. The check method
 // should be called to verify the packet's integrity before invoking
 // message.
 func (p eISCPPacket) message() (*Message, error) {
-	messageSize := p[dataSizeIndex] - dataOverhead
-	m, err := NewMessage(p[messageOffset : messageOffset+messageSize])
+	data := p.data()
+	if len(data) < dataStartSize {
+		return nil, errors.New("packet data is too short")
+	}
+	m, err := NewMessage(data[dataStartSize:])
 	if err != nil {
 		return nil, err
 	}
@@ -102,20 +126,23 @@ func (p eISCPPacket) message() (*Message, error) {
 
 // check performs an integrity check on the packet.
 func (p eISCPPacket) check(endOfPacket byte) error {
+	if err := checkHeader(p); err != nil {
+		return err
+	}
+	size := dataSize(p)
+	data := p[headerSize:]
+	// Some devices terminate the data with CR and/or LF following
+	// the end-of-packet marker.
+	end := bytes.LastIndexByte(data, endOfPacket)
 	switch {
-	case p[0] != 0x49 || p[1] != 0x53 || p[2] != 0x43 || p[3] != 0x50:
-		return errors.New("first 4 header bytes do not match ISCP")
-	case p[headerSize] != 0x21 || p[headerSize+1] != 0x31:
+	case len(data) != size:
+		return fmt.Errorf("data size %v does not match actual size %v",
+			size, len(data))
+	case data[0] != '!' || data[1] != '1':
 		return errors.New("first 2 data bytes do not match !1")
-	case p[headerSizeIndex] != headerSize:
-		return fmt.Errorf("header size %#02x is not expected size %#02x",
-			p[headerSizeIndex], headerSize)
-	case p[dataSizeIndex] > maxDataSize:
-		return fmt.Errorf("data size %#02x greater than max size %#02x",
-			p[dataSizeIndex], maxDataSize)
-	case p[headerSize+p[dataSizeIndex]-1] != endOfPacket:
+	case end < dataStartSize || len(bytes.Trim(data[end+1:], "\r\n\x00")) > 0:
 		return fmt.Errorf("end of packet %#02x did not match expected value %#02x",
-			p[headerSize+p[dataSizeIndex]-1], endOfPacket)
+			data[len(data)-1], endOfPacket)
 	}
 	return nil
 }
@@ -128,7 +155,7 @@ func (p eISCPPacket) debugString() string {
 	buffer.WriteString(fmt.Sprintf("\n"))
 	for i, b := range p {
 		buffer.WriteString(fmt.Sprintf("%#02x", b))
-		if i%4 == 3 {
+		if i%4 == 3 || i == len(p)-1 {
 			buffer.WriteString(fmt.Sprintf("\n"))
 		} else {
 			buffer.WriteString(fmt.Sprint(" "))
@@ -143,11 +170,26 @@ type Message struct {
 	Parameter string
 }
 
+// notAvailable is the parameter sent by the Integra device when a
+// command isn't supported or its value is currently unavailable.
+const notAvailable = "N/A"
+
+// ErrNotAvailable is returned when the Integra device replies "N/A",
+// meaning the command isn't supported or its value is currently
+// unavailable (e.g., because the device is in standby).
+var ErrNotAvailable = errors.New("not available")
+
 // String returns the message as a string.
 func (m *Message) String() string {
 	return m.Command + m.Parameter
 }
 
+// NotAvailable reports whether the message is an "N/A" reply from the
+// Integra device.
+func (m *Message) NotAvailable() bool {
+	return m.Parameter == notAvailable
+}
+
 // NewMessage returns a new Message from the given byte slice making
 // up the message's command and parameter.
 func NewMessage(m []byte) (*Message, error) {
@@ -16,6 +16,7 @@ package integra
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -27,11 +28,11 @@ func TestNewEISCPPacket(t *testing.T) {
 		0x01, 0x00, 0x00, 0x00,
 
 		0x21, 0x31, 0x50, 0x57,
-		0x52, 0x30, 0x30, 0x0a,
-		0x00, 0x00, 0x00, 0x00,
-		0x00, 0x00, 0x00, 0x00}
-	result := newEISCPPacket()
-	_ = result.init("PWR00")
+		0x52, 0x30, 0x30, 0x0a}
+	result := newEISCPPacket("PWR00")
+	if len(result) != len(expected) {
+		t.Fatalf("packet length %v did not match expected %v", len(result), len(expected))
+	}
 	for i, b := range result {
 		if b != expected[i] {
 			t.Errorf("%v did not match expected %v at index %v", b, expected[i], i)
@@ -40,14 +41,21 @@ func TestNewEISCPPacket(t *testing.T) {
 
 }
 
-func TestNewEISCPPacketTooBig(t *testing.T) {
-	expected := "Message 'OVER THE HILLS' too long"
-	packet := newEISCPPacket()
-	result := packet.init("OVER THE HILLS")
-	if result == nil {
-		t.Error("expected non-nil error")
-	} else if result.Error() != expected {
-		t.Errorf("expected error %v but got %v", expected, result)
+func TestNewEISCPPacketLong(t *testing.T) {
+	message := "NTI" + strings.Repeat("Over the Hills and Far Away ", 20)
+	packet := newEISCPPacket(message)
+	if err := packet.check(endOfPacketTx); err != nil {
+		t.Fatal("check failed:", err)
+	}
+	if size := dataSize(packet); size != len(message)+dataOverhead {
+		t.Errorf("data size %v did not match expected %v", size, len(message)+dataOverhead)
+	}
+	m, err := packet.message()
+	if err != nil {
+		t.Fatal("message failed:", err)
+	}
+	if m.String() != message {
+		t.Errorf("%q did not match %q", m.String(), message)
 	}
 }
 
@@ -60,9 +68,7 @@ func TestEISCPPacketMessage(t *testing.T) {
 		0x01, 0x00, 0x00, 0x00,
 
 		0x21, 0x31, 0x50, 0x57,
-		0x52, 0x30, 0x31, 0x0a,
-		0x00, 0x00, 0x00, 0x00,
-		0x00, 0x00, 0x00, 0x00}
+		0x52, 0x30, 0x31, 0x0a}
 	m, _ := packet.message()
 	result := m.String()
 	if result != expected {
@@ -83,11 +89,11 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0x21, 0x31, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x1a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
 		}, nil},
-		{make(eISCPPacket, 32),
+		{make(eISCPPacket, 24),
 			errors.New("first 4 header bytes do not match ISCP")},
+		{make(eISCPPacket, 8),
+			errors.New("packet size 8 is less than header size 16")},
 		{eISCPPacket{
 			0x49, 0x53, 0x43, 0x51,
 			0x00, 0x00, 0x00, 0x10,
@@ -96,8 +102,6 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0x21, 0x31, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x1a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
 		}, errors.New("first 4 header bytes do not match ISCP")},
 		{eISCPPacket{
 			0x49, 0x53, 0x43, 0x50,
@@ -107,8 +111,6 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0x20, 0x31, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x1a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
 		}, errors.New("first 2 data bytes do not match !1")},
 		{eISCPPacket{
 			0x49, 0x53, 0x43, 0x50,
@@ -118,8 +120,6 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0xff, 0xff, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x1a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
 		}, errors.New("first 2 data bytes do not match !1")},
 		{eISCPPacket{
 			0x49, 0x53, 0x43, 0x50,
@@ -129,8 +129,6 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0x21, 0x31, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x1a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
 		}, errors.New("header size 0x11 is not expected size 0x10")},
 		{eISCPPacket{
 			0x49, 0x53, 0x43, 0x50,
@@ -140,9 +138,26 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0x21, 0x31, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x1a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
-		}, errors.New("data size 0x11 greater than max size 0x10")},
+		}, errors.New("data size 17 does not match actual size 8")},
+		{eISCPPacket{
+			0x49, 0x53, 0x43, 0x50,
+			0x00, 0x00, 0x00, 0x10,
+			0x00, 0x20, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+
+			0x21, 0x31, 0x50, 0x57,
+			0x52, 0x30, 0x31, 0x1a,
+		}, errors.New("data size 2097152 greater than max size 1048576")},
+		{eISCPPacket{
+			0x49, 0x53, 0x43, 0x50,
+			0x00, 0x00, 0x00, 0x10,
+			0x00, 0x00, 0x00, 0x0a,
+			0x01, 0x00, 0x00, 0x00,
+
+			0x21, 0x31, 0x50, 0x57,
+			0x52, 0x30, 0x31, 0x1a,
+			0x0d, 0x0a,
+		}, nil},
 		{eISCPPacket{
 			0x49, 0x53, 0x43, 0x50,
 			0x00, 0x00, 0x00, 0x10,
@@ -151,8 +166,6 @@ func TestCheckEISPPacket(t *testing.T) {
 
 			0x21, 0x31, 0x50, 0x57,
 			0x52, 0x30, 0x31, 0x0a,
-			0x00, 0x00, 0x00, 0x00,
-			0x00, 0x00, 0x00, 0x00,
 		}, errors.New("end of packet 0x0a did not match expected value 0x1a")},
 	}
 	for _, test := range tests {
@@ -175,9 +188,7 @@ func TestDebugString(t *testing.T) {
 		0x01, 0x00, 0x00, 0x00,
 
 		0x21, 0x31, 0x50, 0x57,
-		0x52, 0x30, 0x31, 0x0a,
-		0x00, 0x00, 0x00, 0x00,
-		0x00, 0x00, 0x00, 0x00}
+		0x52, 0x30, 0x31, 0x0a}
 	expected := `
 0x49 0x53 0x43 0x50
 0x00 0x00 0x00 0x10
@@ -185,8 +196,6 @@ func TestDebugString(t *testing.T) {
 0x01 0x00 0x00 0x00
 0x21 0x31 0x50 0x57
 0x52 0x30 0x31 0x0a
-0x00 0x00 0x00 0x00
-0x00 0x00 0x00 0x00
 `
 	if result := packet.debugString(); result != expected {
 		t.Errorf("%v did not match %v", result, expected)
@@ -0,0 +1,145 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import "time"
+
+// An Option configures a Device. Options are passed to Connect and
+// the other Connect functions.
+type Option func(*Device)
+
+// ReconnectBackoff sets the delays used when reconnecting after the
+// connection to the Integra device is lost. The delay before the
+// first attempt is min and doubles after each failed attempt up to
+// max. The defaults are 1 second and 30 seconds. A min of zero or
+// less is replaced by the default, and a max less than min by min.
+func ReconnectBackoff(min, max time.Duration) Option {
+	return func(d *Device) {
+		if min <= 0 {
+			min = defaultMinBackoff
+		}
+		if max < min {
+			max = min
+		}
+		d.minBackoff = min
+		d.maxBackoff = max
+	}
+}
+
+// WriteTimeout sets the deadline for each write to the Integra
+// device. A write that times out is treated as a lost connection. The
+// default is 10 seconds; zero disables the deadline.
+func WriteTimeout(timeout time.Duration) Option {
+	return func(d *Device) {
+		d.writeTimeout = timeout
+	}
+}
+
+// SkipValidation disables checking messages against the command
+// catalog before they are sent, e.g., to send commands the catalog
+// doesn't know about for a particular model.
+func SkipValidation() Option {
+	return func(d *Device) {
+		d.skipValidation = true
+	}
+}
+
+// CommandGap sets the minimum delay between messages written to the
+// Integra device, which tends to drop messages sent back-to-back.
+// The default is 50 milliseconds.
+func CommandGap(gap time.Duration) Option {
+	return func(d *Device) {
+		d.commandGap = gap
+	}
+}
+
+// WaitForEcho causes each message written to the Integra device to be
+// followed by a wait of up to timeout for the device to reply with the
+// same command before the next message is written. Zero, the default,
+// disables waiting.
+func WaitForEcho(timeout time.Duration) Option {
+	return func(d *Device) {
+		d.echoTimeout = timeout
+	}
+}
+
+// CoalesceCommands enables coalescing of messages waiting to be
+// written to the Integra device. A message setting an absolute value,
+// such as MVL2A, replaces a waiting message for the same command, so
+// that the device ends up at the latest value without working through
+// a backlog; Send returns nil for the replaced message and
+// Client.SendConfirm returns ErrSuperseded. Step messages
+// such as MVLUP are limited to one per command per stepInterval;
+// Send returns ErrRateLimited for the others. Zero disables rate
+// limiting.
+func CoalesceCommands(stepInterval time.Duration) Option {
+	return func(d *Device) {
+		d.coalesceCommands = true
+		d.stepInterval = stepInterval
+	}
+}
+
+// PowerOnSettle holds messages other than power messages once a
+// power on message, such as PWR01, is written, until the Integra
+// device echoes it and the given delay passes, since devices ignore
+// most commands while powering on. Held messages are then written in
+// order. Nothing is held if the device already reports the zone on.
+// Messages are held for at most MaxPowerOnHold in case the device
+// never becomes ready, so senders' deadlines should allow for it. See
+// also PowerOnProbe.
+func PowerOnSettle(delay time.Duration) Option {
+	return func(d *Device) {
+		d.powerOnSettle = delay
+	}
+}
+
+// PowerOnProbe holds messages like PowerOnSettle and additionally
+// queries the given command (e.g., "SLI") after the settle delay until
+// the Integra device replies with a value, at which point it is
+// considered ready.
+func PowerOnProbe(command string) Option {
+	return func(d *Device) {
+		d.powerOnProbe = command
+	}
+}
+
+// PopulateState queries the given commands (e.g., "PWR", "MVL") after
+// connecting so that the state is complete from the start instead of
+// being built up from the messages the Integra device happens to send.
+// With no commands, every command in the catalog that can be queried
+// is; the device replies N/A to those it doesn't support, leaving
+// them out of the state. Device.StateReady reports when the replies
+// are in.
+func PopulateState(commands ...string) Option {
+	return func(d *Device) {
+		d.populateOnConnect = true
+		d.populate = commands
+	}
+}
+
+// Poll queries the given commands (by default, "PWR") every interval.
+// Replies update the state, correcting values the Integra device
+// failed to report, e.g., after network standby transitions. If the
+// device leaves two polls in a row unanswered, the connection is
+// considered dead and the Device reconnects.
+func Poll(interval time.Duration, commands ...string) Option {
+	return func(d *Device) {
+		if len(commands) == 0 {
+			commands = []string{CmdPower}
+		}
+		d.pollInterval = interval
+		d.pollCommands = commands
+	}
+}
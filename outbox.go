@@ -0,0 +1,359 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultCommandGap is the default minimum delay between
+	// messages written to the Integra device. Integra devices tend
+	// to drop messages sent back-to-back.
+	defaultCommandGap = 50 * time.Millisecond
+)
+
+// ErrRateLimited is returned by Client.Send for a step message such
+// as MVLUP that was dropped because another step for the same command
+// was sent too recently. See CoalesceCommands.
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrSuperseded is returned by Client.SendConfirm when the message was
+// replaced by a newer one for the same command before being written.
+// See CoalesceCommands.
+var ErrSuperseded = errors.New("superseded")
+
+// A Priority orders messages waiting to be written to the Integra
+// device. Messages with higher priority are written first; messages
+// with the same priority are written in the order they were sent.
+type Priority int
+
+const (
+	NormalPriority Priority = iota
+	// HighPriority is used by default for messages putting the
+	// device or a zone in standby.
+	HighPriority
+)
+
+// powerCommands are the commands whose standby messages are sent
+// with HighPriority by default.
+var powerCommands = map[string]bool{
+	CmdPower:      true,
+	CmdZone2Power: true,
+	CmdZone3Power: true,
+	CmdZone4Power: true,
+}
+
+// priorityOf returns the default priority of m.
+func priorityOf(m *Message) Priority {
+	if powerCommands[m.Command] && m.Parameter == string(PowerStandby) {
+		return HighPriority
+	}
+	return NormalPriority
+}
+
+// stepParams are parameters that change a value relative to its
+// current value.
+var stepParams = map[string]bool{
+	"UP": true, "DOWN": true, "UP1": true, "DOWN1": true,
+	"BUP": true, "BDOWN": true, "TUP": true, "TDOWN": true,
+}
+
+// cycleParams are parameters that toggle or cycle through values, so
+// that sending one twice differs from sending it once.
+var cycleParams = map[string]bool{
+	"TG": true, "DIM": true, "MOVIE": true, "MUSIC": true, "GAME": true,
+}
+
+// coalescable reports whether m sets an absolute value, e.g., MVL2A,
+// making any waiting message that sets the same command redundant.
+func coalescable(m *Message) bool {
+	c, ok := LookupCommand(m.Command)
+	if !ok || !c.Query {
+		// Commands without state, e.g., NTC, are actions.
+		return false
+	}
+	p := m.Parameter
+	return p != "QSTN" && !stepParams[p] && !cycleParams[p]
+}
+
+// coalesce applies CoalesceCommands to request, which has just been
+// queued. It reports whether request was handled, either by replacing
+// a waiting request or by being rejected.
+func (d *Device) coalesce(pending *outbox, request *sendRequest) bool {
+	m := request.message
+	if stepParams[m.Parameter] {
+		if !d.steps.take(m.Command, d.stepInterval, time.Now()) {
+			request.err <- ErrRateLimited
+			return true
+		}
+		return false
+	}
+	if !coalescable(m) {
+		return false
+	}
+	for i, waiting := range *pending {
+		if waiting.message.Command != m.Command || !coalescable(waiting.message) {
+			continue
+		}
+		// Send the new value in place of the old one. The
+		// old value would be overwritten anyway, so Send
+		// tells its sender it succeeded.
+		log.Printf("Coalescing %v into %v\n", waiting.message, m)
+		waiting.err <- ErrSuperseded
+		request.seq = waiting.seq
+		if waiting.priority > request.priority {
+			request.priority = waiting.priority
+		}
+		(*pending)[i] = request
+		heap.Fix(pending, i)
+		return true
+	}
+	return false
+}
+
+// stepLimiter records when a step message was last accepted for each
+// command.
+type stepLimiter struct {
+	sync.Mutex
+	last map[string]time.Time
+}
+
+// take reports whether a step message for command may be sent at now
+// given the minimum interval between steps, and if so records it.
+func (l *stepLimiter) take(command string, interval time.Duration, now time.Time) bool {
+	l.Lock()
+	defer l.Unlock()
+	if interval <= 0 {
+		return true
+	}
+	if last, ok := l.last[command]; ok && now.Sub(last) < interval {
+		return false
+	}
+	if l.last == nil {
+		l.last = make(map[string]time.Time)
+	}
+	l.last[command] = now
+	return true
+}
+
+// check returns an error wrapping ErrRateLimited if any step message
+// in messages would be rejected if they were sent now, one after the
+// other. Nothing is recorded.
+func (l *stepLimiter) check(messages []*Message, interval time.Duration, now time.Time) error {
+	l.Lock()
+	defer l.Unlock()
+	if interval <= 0 {
+		return nil
+	}
+	taken := make(map[string]bool)
+	for _, m := range messages {
+		if !stepParams[m.Parameter] {
+			continue
+		}
+		last, ok := l.last[m.Command]
+		if taken[m.Command] || ok && now.Sub(last) < interval {
+			return fmt.Errorf("%v: %w", m, ErrRateLimited)
+		}
+		taken[m.Command] = true
+	}
+	return nil
+}
+
+// CheckRateLimit returns an error wrapping ErrRateLimited if sending
+// messages in order now would have any of them rejected by the step
+// rate limit of CoalesceCommands. Checking a batch first avoids
+// sending only part of it.
+func (c *Client) CheckRateLimit(messages ...*Message) error {
+	if !c.device.coalesceCommands {
+		return nil
+	}
+	return c.device.steps.check(messages, c.device.stepInterval, time.Now())
+}
+
+// outbox is a priority queue of send requests implementing
+// heap.Interface.
+type outbox []*sendRequest
+
+func (o outbox) Len() int { return len(o) }
+
+func (o outbox) Less(i, j int) bool {
+	if o[i].priority != o[j].priority {
+		return o[i].priority > o[j].priority
+	}
+	return o[i].seq < o[j].seq
+}
+
+func (o outbox) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+
+func (o *outbox) Push(x interface{}) { *o = append(*o, x.(*sendRequest)) }
+
+// next returns the index of the request to write next. While gated,
+// only requests that bypass the power on gate are eligible.
+func (o outbox) next(gated bool) (int, bool) {
+	if !gated {
+		return 0, len(o) > 0
+	}
+	next := -1
+	for i, request := range o {
+		if request.ungated && (next < 0 || o.Less(i, next)) {
+			next = i
+		}
+	}
+	return next, next >= 0
+}
+
+func (o *outbox) Pop() interface{} {
+	old := *o
+	r := old[len(old)-1]
+	old[len(old)-1] = nil
+	*o = old[:len(old)-1]
+	return r
+}
+
+// QueueDepth returns the number of messages waiting to be written to
+// the Integra device.
+func (d *Device) QueueDepth() int {
+	return int(atomic.LoadInt64(&d.queued))
+}
+
+// writeLoop runs in its own goroutine and writes the messages sent by
+// clients to the transport in priority order, leaving at least
+// commandGap between writes and optionally waiting for the device to
+// echo each command.
+func (d *Device) writeLoop() {
+	defer d.loops.Done()
+	var pending outbox
+	var seq uint64
+	var last time.Time
+	// powering is non-nil while the device is powering on; it is
+	// closed when the device is ready for other messages.
+	var powering <-chan struct{}
+	for {
+		var ready <-chan time.Time
+		next, ok := pending.next(powering != nil)
+		if ok {
+			ready = time.After(time.Until(last.Add(d.commandGap)))
+		}
+		select {
+		case <-d.done:
+			for _, request := range pending {
+				request.err <- ErrClosed
+			}
+			atomic.AddInt64(&d.queued, -int64(len(pending)))
+			return
+		case request := <-d.send:
+			if d.coalesceCommands && d.coalesce(&pending, request) {
+				atomic.AddInt64(&d.queued, -1)
+				continue
+			}
+			seq++
+			request.seq = seq
+			heap.Push(&pending, request)
+		case <-powering:
+			log.Println("Device ready after power on")
+			powering = nil
+		case <-ready:
+			request := heap.Remove(&pending, next).(*sendRequest)
+			atomic.AddInt64(&d.queued, -1)
+			if err := request.ctx.Err(); err != nil {
+				// The sender gave up while the message
+				// was queued; don't spend a gap on it.
+				request.err <- err
+				continue
+			}
+			if d.gatesPowerOn(request.message) {
+				powering = d.powerOn(request)
+			} else {
+				d.writePaced(request)
+			}
+			last = time.Now()
+		}
+	}
+}
+
+// writePaced writes the message in request and, if configured, waits
+// for the device to echo its command before returning.
+func (d *Device) writePaced(request *sendRequest) {
+	if d.echoTimeout <= 0 {
+		request.err <- d.write(request)
+		return
+	}
+	command := request.message.Command
+	echo, cancel := d.await(command)
+	defer cancel()
+	err := d.write(request)
+	request.err <- err
+	if err != nil {
+		return
+	}
+	select {
+	case <-echo:
+	case <-time.After(d.echoTimeout):
+		log.Printf("No reply to %v within %v\n", request.message, d.echoTimeout)
+	case <-d.done:
+	}
+}
+
+// newSendRequest returns a request to write m with the given
+// priority. Power messages are not held while the device powers on.
+func newSendRequest(ctx context.Context, m *Message, priority Priority) *sendRequest {
+	return &sendRequest{
+		ctx:      ctx,
+		message:  m,
+		priority: priority,
+		ungated:  powerCommands[m.Command],
+		err:      make(chan error, 1),
+	}
+}
+
+// sendMessage queues the given message to be written to the Integra
+// device and waits until it has been written.
+func (d *Device) sendMessage(ctx context.Context, m *Message, priority Priority) error {
+	return d.enqueue(newSendRequest(ctx, m, priority))
+}
+
+// enqueue queues request to be written to the Integra device and
+// waits until it has been written.
+func (d *Device) enqueue(request *sendRequest) error {
+	ctx := request.ctx
+	atomic.AddInt64(&d.queued, 1)
+	select {
+	case d.send <- request:
+	case <-d.done:
+		atomic.AddInt64(&d.queued, -1)
+		return ErrClosed
+	case <-ctx.Done():
+		atomic.AddInt64(&d.queued, -1)
+		return ctx.Err()
+	}
+	select {
+	case err := <-request.err:
+		return err
+	case <-d.done:
+		// writeLoop may have exited before taking request
+		// from the queue.
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
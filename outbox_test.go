@@ -0,0 +1,170 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitQueueDepth waits until device has n messages waiting to be
+// written.
+func waitQueueDepth(tb testing.TB, device *Device, n int) {
+	tb.Helper()
+	deadline := time.Now().Add(time.Second)
+	for device.QueueDepth() != n {
+		if time.Now().After(deadline) {
+			tb.Fatalf("QueueDepth is %v, expected %v", device.QueueDepth(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCommandGap(t *testing.T) {
+	const gap = 30 * time.Millisecond
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(gap))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	for _, m := range []string{"MVL20", "MVL21", "MVL22"} {
+		go client.Send(&Message{m[:3], m[3:]})
+		waitQueueDepth(t, device, 0)
+	}
+	var last time.Time
+	for i := 0; i < 3; i++ {
+		select {
+		case <-transport.out:
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for write")
+		}
+		now := time.Now()
+		if i > 0 && now.Sub(last) < gap-5*time.Millisecond {
+			t.Errorf("write %v followed the previous one after %v, expected at least %v", i, now.Sub(last), gap)
+		}
+		last = now
+	}
+}
+
+func TestPriority(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(100*time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"MVL", "20"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	// The following messages wait for the gap after MVL20.
+	for i, m := range []string{"MVL21", "MVL22", "PWR00"} {
+		go client.Send(&Message{m[:3], m[3:]})
+		waitQueueDepth(t, device, i+1)
+	}
+	for _, expected := range []string{"MVL20", "PWR00", "MVL21", "MVL22"} {
+		transport.expectWritten(t, expected)
+	}
+	waitQueueDepth(t, device, 0)
+}
+
+func TestWaitForEcho(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0), WaitForEcho(time.Second))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	go client.Send(&Message{"PWR", "01"})
+	transport.expectWritten(t, "PWR01")
+	go client.Send(&Message{"MVL", "20"})
+	select {
+	case m := <-transport.out:
+		t.Fatalf("wrote %v before PWR01 was echoed", m)
+	case <-time.After(20 * time.Millisecond):
+	}
+	transport.in <- &Message{"PWR", "01"}
+	transport.expectWritten(t, "MVL20")
+}
+
+func TestCoalesceCommands(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport),
+		CommandGap(100*time.Millisecond), CoalesceCommands(time.Hour))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"PWR", "01"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	// The following messages wait for the gap after PWR01.
+	errs := make(chan error, 10)
+	for i, m := range []string{"MVL20", "AMTTG", "MVL21", "AMTTG", "MVLQSTN", "MVL22"} {
+		go func(m string) { errs <- client.Send(&Message{m[:3], m[3:]}) }(m)
+		depth := []int{1, 2, 2, 3, 4, 4}[i]
+		waitQueueDepth(t, device, depth)
+	}
+	if err := client.Send(&Message{"MVL", "UP"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	if err := client.Send(&Message{"MVL", "UP"}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Send returned %v, expected %v", err, ErrRateLimited)
+	}
+	for _, expected := range []string{"PWR01", "MVL22", "AMTTG", "AMTTG", "MVLQSTN", "MVLUP"} {
+		transport.expectWritten(t, expected)
+	}
+	for i := 0; i < 6; i++ {
+		if err := <-errs; err != nil {
+			t.Error("Send failed:", err)
+		}
+	}
+}
+
+func TestCheckRateLimit(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0), CoalesceCommands(time.Hour))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	up, down := &Message{"MVL", "UP"}, &Message{"TFR", "BDOWN"}
+	if err := client.CheckRateLimit(up, down, &Message{"PWR", "01"}); err != nil {
+		t.Error("CheckRateLimit failed:", err)
+	}
+	if err := client.CheckRateLimit(up, down, up); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("CheckRateLimit returned %v, expected %v", err, ErrRateLimited)
+	}
+	if err := client.Send(up); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	if err := client.CheckRateLimit(down, up); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("CheckRateLimit returned %v, expected %v", err, ErrRateLimited)
+	}
+	if err := client.CheckRateLimit(down); err != nil {
+		t.Error("CheckRateLimit failed:", err)
+	}
+}
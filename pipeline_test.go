@@ -0,0 +1,184 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingTransport is a fakeTransport whose writes block until
+// unblock is closed.
+type blockingTransport struct {
+	*fakeTransport
+	unblock chan struct{}
+}
+
+func (t *blockingTransport) WriteMessage(m *Message) error {
+	select {
+	case <-t.unblock:
+	case <-t.closed:
+	}
+	return t.fakeTransport.WriteMessage(m)
+}
+
+func TestSlowWriteDoesNotBlockReceive(t *testing.T) {
+	transport := &blockingTransport{newFakeTransport(), make(chan struct{})}
+	device, err := ConnectTransport(func(ctx context.Context) (Transport, error) {
+		return transport, nil
+	})
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	messages := collect(device.NewClient())
+
+	sent := make(chan error, 1)
+	go func() {
+		sent <- device.NewSendOnlyClient().Send(&Message{"PWR", "01"})
+	}()
+	// The write is blocked, yet messages are still received and
+	// dispatched.
+	transport.in <- &Message{"MVL", "20"}
+	expectReceived(t, messages, "MVL20")
+
+	close(transport.unblock)
+	if err := <-sent; err != nil {
+		t.Error("Send failed:", err)
+	}
+	transport.expectWritten(t, "PWR01")
+}
+
+// quiet discards log output for the rest of the benchmark.
+func quiet(b *testing.B) {
+	log.SetOutput(ioutil.Discard)
+	b.Cleanup(func() { log.SetOutput(os.Stderr) })
+}
+
+func BenchmarkDispatch(b *testing.B) {
+	for _, clients := range []int{1, 100, 500} {
+		b.Run(fmt.Sprintf("clients=%d", clients), func(b *testing.B) {
+			quiet(b)
+			transport := newFakeTransport()
+			device, err := ConnectTransport(fakeDialer(transport))
+			if err != nil {
+				b.Fatal("ConnectTransport failed:", err)
+			}
+			defer device.Close()
+
+			// Each client reads until it receives PWR01, which is
+			// sent last. Coalescing keeps slow clients attached.
+			var wg sync.WaitGroup
+			for i := 0; i < clients; i++ {
+				client := device.Subscribe(nil, Overflow(Coalesce))
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for {
+						m, err := client.Receive()
+						if err != nil || m.Command == "PWR" {
+							return
+						}
+					}
+				}()
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				transport.in <- &Message{"MVL", fmt.Sprintf("%02X", i%0x65)}
+			}
+			transport.in <- &Message{"PWR", "01"}
+			wg.Wait()
+		})
+	}
+}
+
+func BenchmarkSend(b *testing.B) {
+	for _, clients := range []int{1, 100, 500} {
+		b.Run(fmt.Sprintf("clients=%d", clients), func(b *testing.B) {
+			quiet(b)
+			transport := newFakeTransport()
+			device, err := ConnectTransport(fakeDialer(transport), CommandGap(0))
+			if err != nil {
+				b.Fatal("ConnectTransport failed:", err)
+			}
+			defer device.Close()
+			go func() {
+				for {
+					select {
+					case <-transport.out:
+					case <-transport.closed:
+						return
+					}
+				}
+			}()
+			// Receiving clients keep the dispatcher busy while
+			// messages are sent.
+			for i := 0; i < clients; i++ {
+				collect(device.Subscribe(nil, Overflow(DropOldest)))
+			}
+			go func() {
+				for {
+					select {
+					case transport.in <- &Message{"MVL", "20"}:
+					case <-transport.closed:
+						return
+					}
+				}
+			}()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				client := device.NewSendOnlyClient()
+				m := &Message{"MVL", "UP"}
+				for pb.Next() {
+					if err := client.Send(m); err != nil {
+						b.Error("Send failed:", err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkSlowWrite(b *testing.B) {
+	quiet(b)
+	transport := &blockingTransport{newFakeTransport(), make(chan struct{})}
+	device, err := ConnectTransport(func(ctx context.Context) (Transport, error) {
+		return transport, nil
+	})
+	if err != nil {
+		b.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	go device.NewSendOnlyClient().Send(&Message{"PWR", "01"})
+	client := device.Subscribe(nil, BufferSize(b.N+1))
+	time.Sleep(time.Millisecond)
+
+	// Receiving isn't held up by the blocked write.
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		transport.in <- &Message{"MVL", "20"}
+		if _, err := client.Receive(); err != nil {
+			b.Fatal("Receive failed:", err)
+		}
+	}
+}
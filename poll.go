@@ -0,0 +1,104 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"log"
+	"time"
+)
+
+const (
+	// maxPollTimeout bounds the wait for the reply to each poll
+	// query once it has been written.
+	maxPollTimeout = 5 * time.Second
+	// pollMisses is the number of consecutive unanswered polls
+	// after which the connection is considered dead.
+	pollMisses = 2
+)
+
+// pollLoop runs in its own goroutine if the Poll option is given and
+// queries the poll commands every pollInterval. Replies update the
+// state like any other message, correcting values the device failed
+// to report. If the device doesn't answer pollMisses polls in a row,
+// pollLoop closes the transport so that the Device reconnects.
+func (d *Device) pollLoop() {
+	defer d.loops.Done()
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+	misses := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-d.done:
+			return
+		}
+		if d.ConnState() != Connected {
+			misses = 0
+			continue
+		}
+		if d.poll() {
+			misses = 0
+			continue
+		}
+		misses++
+		log.Printf("No reply to poll (%v in a row)\n", misses)
+		if misses < pollMisses {
+			continue
+		}
+		misses = 0
+		if t := d.transport(); t != nil {
+			log.Println("Connection appears dead; reconnecting")
+			_ = t.Close()
+		}
+	}
+}
+
+// poll sends a QSTN message for each poll command and reports whether
+// the device answered any of them.
+func (d *Device) poll() bool {
+	timeout := d.pollInterval
+	if timeout > maxPollTimeout {
+		timeout = maxPollTimeout
+	}
+	answered := false
+	for _, command := range d.pollCommands {
+		if d.pollCommand(command, timeout) {
+			answered = true
+		}
+	}
+	return answered
+}
+
+// pollCommand queries command and reports whether the device
+// answered within timeout of the query being written. Time spent
+// waiting to be written, e.g., behind the command gap, doesn't count
+// against the device.
+func (d *Device) pollCommand(command string, timeout time.Duration) bool {
+	d.state.RLock()
+	old := d.state.m[command].Parameter
+	d.state.RUnlock()
+	// Polls aren't held while the device powers on, so that they
+	// keep checking the connection.
+	request := newSendRequest(d.ctx, &Message{command, "QSTN"}, NormalPriority)
+	request.ungated = true
+	m, err := d.roundTrip(request, timeout)
+	if err != nil {
+		return false
+	}
+	if old != "" && m.Parameter != old {
+		log.Printf("Poll found %v changed from %q to %q\n", command, old, m.Parameter)
+	}
+	return true
+}
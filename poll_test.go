@@ -0,0 +1,107 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollReconcilesState(t *testing.T) {
+	transport := newFakeTransport()
+	go transport.serve(map[string]string{"PWR": "01", "MVL": "2A"})
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0),
+		Poll(10*time.Millisecond, "PWR", "MVL"))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewClient()
+	messages := collect(client)
+
+	// The device reported standby but then powered on without
+	// saying so.
+	transport.in <- &Message{"PWR", "00"}
+	expectReceived(t, messages, "PWR00")
+	deadline := time.Now().Add(time.Second)
+	for {
+		state := client.State()
+		if state["PWR"] == "01" && state["MVL"] == "2A" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("state %v, expected PWR:01 and MVL:2A", state)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if state := device.ConnState(); state != Connected {
+		t.Errorf("ConnState is %v, expected %v", state, Connected)
+	}
+}
+
+func TestPollReconnects(t *testing.T) {
+	// The first transport is half-open: writes succeed but nothing
+	// is ever received.
+	dead, alive := newFakeTransport(), newFakeTransport()
+	go alive.serve(map[string]string{"PWR": "01"})
+	device, err := ConnectTransport(fakeDialer(dead, alive), CommandGap(0),
+		Poll(10*time.Millisecond), ReconnectBackoff(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	events := make(chan ConnEvent, 10)
+	device.NotifyConnState(events)
+
+	for _, expected := range []ConnState{Disconnected, Connecting, Connected} {
+		select {
+		case event := <-events:
+			if event.State != expected {
+				t.Errorf("got %v event, expected %v", event, expected)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %v event", expected)
+		}
+	}
+	select {
+	case <-dead.closed:
+	default:
+		t.Error("dead transport was not closed")
+	}
+}
+
+func TestPollBusyOutbox(t *testing.T) {
+	transport := newFakeTransport()
+	go transport.serve(map[string]string{"PWR": "01"})
+	// Each poll waits for the gap longer than the poll interval.
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(50*time.Millisecond),
+		Poll(10*time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	events := make(chan ConnEvent, 10)
+	device.NotifyConnState(events)
+
+	client := device.NewSendOnlyClient()
+	for i := 0; i < 5; i++ {
+		go client.Send(&Message{"MVL", "20"})
+	}
+	select {
+	case event := <-events:
+		t.Errorf("got %v event, expected the connection to stay up", event)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// populateTimeout bounds the wait for each reply when populating the
+// state.
+const populateTimeout = 2 * time.Second
+
+// StateReady returns a channel that is closed once the state has been
+// populated after connecting. See PopulateState. Without that option,
+// the channel is closed from the start.
+func (d *Device) StateReady() <-chan struct{} {
+	return d.stateReady
+}
+
+// PopulateTimeout returns an upper bound on the time from connecting
+// until StateReady is closed, allowing for every query to be paced,
+// held while the device powers on and left unanswered.
+func (d *Device) PopulateTimeout() time.Duration {
+	if !d.populateOnConnect {
+		return 0
+	}
+	n := time.Duration(len(d.populateCommands()))
+	timeout := n*(d.commandGap+d.echoTimeout) + populateTimeout
+	if d.powerOnSettle > 0 || d.powerOnProbe != "" {
+		timeout += MaxPowerOnHold
+	}
+	return timeout
+}
+
+// populateCommands returns the commands to query when populating the
+// state: those given to PopulateState or, by default, every command
+// in the catalog that can be queried.
+func (d *Device) populateCommands() []string {
+	if len(d.populate) > 0 {
+		return d.populate
+	}
+	var commands []string
+	for _, c := range Commands() {
+		if c.Query {
+			commands = append(commands, c.Code)
+		}
+	}
+	return commands
+}
+
+// populateState sends a QSTN message for each command to populate
+// and closes stateReady once every query has been answered or has
+// timed out. Commands the device doesn't support are answered N/A
+// and left out of the state.
+func (d *Device) populateState() {
+	defer close(d.stateReady)
+	commands := d.populateCommands()
+	log.Printf("Populating state with %v queries\n", len(commands))
+	// The outbox paces the queries; the replies are awaited
+	// concurrently.
+	var wg sync.WaitGroup
+	for _, command := range commands {
+		wg.Add(1)
+		go func(command string) {
+			defer wg.Done()
+			m := &Message{command, "QSTN"}
+			request := newSendRequest(d.ctx, m, NormalPriority)
+			_, err := d.roundTrip(request, populateTimeout)
+			if err != nil && d.Err() == nil {
+				log.Printf("Populating %v failed: %v\n", command, err)
+			}
+		}(command)
+	}
+	wg.Wait()
+	log.Println("State populated")
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPopulateState(t *testing.T) {
+	transport := newFakeTransport()
+	go transport.serve(map[string]string{"PWR": "01", "MVL": "2A", "SLI": "03"})
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0),
+		PopulateState("PWR", "MVL", "TUN"))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	select {
+	case <-device.StateReady():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for StateReady")
+	}
+	expected := map[string]string{"PWR": "01", "MVL": "2A"}
+	if state := device.NewSendOnlyClient().State(); !reflect.DeepEqual(state, expected) {
+		t.Errorf("state %v, expected %v", state, expected)
+	}
+}
+
+func TestStateReadyWithoutPopulate(t *testing.T) {
+	device, err := ConnectTransport(fakeDialer(newFakeTransport()))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	select {
+	case <-device.StateReady():
+	default:
+		t.Error("StateReady not closed without PopulateState")
+	}
+}
+
+func TestPopulateCommands(t *testing.T) {
+	d := &Device{}
+	commands := make(map[string]bool)
+	for _, command := range d.populateCommands() {
+		commands[command] = true
+	}
+	for command, expected := range map[string]bool{
+		CmdPower: true, CmdMasterVolume: true, CmdZone2Volume: true, CmdZone4InputSelector: true,
+		CmdTuner: true, CmdSetup: false,
+	} {
+		if commands[command] != expected {
+			t.Errorf("populateCommands() includes %v: %v, expected %v", command, commands[command], expected)
+		}
+	}
+	d.populate = []string{"PWR"}
+	if commands := d.populateCommands(); !reflect.DeepEqual(commands, []string{"PWR"}) {
+		t.Errorf("populateCommands() returned %v, expected [PWR]", commands)
+	}
+}
+
+func TestPopulateTimeout(t *testing.T) {
+	d := &Device{commandGap: 50 * time.Millisecond}
+	if timeout := d.PopulateTimeout(); timeout != 0 {
+		t.Errorf("PopulateTimeout() returned %v without PopulateState, expected 0", timeout)
+	}
+	PopulateState("PWR", "MVL")(d)
+	if timeout, expected := d.PopulateTimeout(), 100*time.Millisecond+populateTimeout; timeout != expected {
+		t.Errorf("PopulateTimeout() returned %v, expected %v", timeout, expected)
+	}
+	PowerOnSettle(time.Second)(d)
+	if timeout, expected := d.PopulateTimeout(), 100*time.Millisecond+populateTimeout+MaxPowerOnHold; timeout != expected {
+		t.Errorf("PopulateTimeout() returned %v, expected %v", timeout, expected)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// MaxPowerOnHold bounds the time messages are held while the device
+// powers on (see PowerOnSettle). Deadlines for sending messages that
+// may be held should allow for it.
+const MaxPowerOnHold = 15 * time.Second
+
+// probeInterval is the delay between PowerOnProbe queries.
+const probeInterval = 500 * time.Millisecond
+
+// gatesPowerOn reports whether m powers the device or a zone on and
+// other messages should be held until the device is ready. A zone
+// already reported on is ready, so messages aren't held for it.
+func (d *Device) gatesPowerOn(m *Message) bool {
+	if d.powerOnSettle <= 0 && d.powerOnProbe == "" {
+		return false
+	}
+	if !powerCommands[m.Command] || m.Parameter != string(PowerOn) {
+		return false
+	}
+	d.state.RLock()
+	defer d.state.RUnlock()
+	return d.state.m[m.Command].Parameter != string(PowerOn)
+}
+
+// powerOn writes the power on message in request. The returned
+// channel is closed when the device is ready for other messages.
+func (d *Device) powerOn(request *sendRequest) <-chan struct{} {
+	ready := make(chan struct{})
+	// Register before writing so that a prompt echo isn't missed.
+	echo, cancel := d.await(request.message.Command)
+	err := d.write(request)
+	request.err <- err
+	if err != nil {
+		cancel()
+		close(ready)
+		return ready
+	}
+	go func() {
+		defer close(ready)
+		defer cancel()
+		ctx, cancelCtx := context.WithTimeout(d.ctx, MaxPowerOnHold)
+		defer cancelCtx()
+		if err := d.awaitPowerOn(ctx, request.message, echo); err != nil {
+			log.Printf("Releasing messages held after %v: %v\n", request.message, err)
+		}
+	}()
+	return ready
+}
+
+// awaitPowerOn waits for the device to echo the power on message m,
+// then for the settle delay and a reply to the probe query, if
+// configured.
+func (d *Device) awaitPowerOn(ctx context.Context, m *Message, echo <-chan *Message) error {
+	select {
+	case reply := <-echo:
+		if reply.Parameter != m.Parameter {
+			// The device didn't power on, so it isn't
+			// going to become ready.
+			return nil
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-time.After(d.powerOnSettle):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if d.powerOnProbe == "" {
+		return nil
+	}
+	for {
+		ok, err := d.probe(ctx)
+		if ok || err != nil {
+			return err
+		}
+		select {
+		case <-time.After(probeInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// probe queries the PowerOnProbe command and reports whether the
+// device replied with a value.
+func (d *Device) probe(ctx context.Context) (bool, error) {
+	request := newSendRequest(ctx, &Message{d.powerOnProbe, "QSTN"}, NormalPriority)
+	request.ungated = true
+	reply, err := d.roundTrip(request, probeInterval)
+	if err == errNoReply {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !reply.NotAvailable(), nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPowerOnSettle(t *testing.T) {
+	const settle = 50 * time.Millisecond
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0), PowerOnSettle(settle))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"PWR", "01"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	transport.expectWritten(t, "PWR01")
+	for i, m := range []string{"SLI03", "MVL28"} {
+		go client.Send(&Message{m[:3], m[3:]})
+		waitQueueDepth(t, device, i+1)
+	}
+	// Power messages aren't held.
+	go client.Send(&Message{"ZPW", "00"})
+	transport.expectWritten(t, "ZPW00")
+	select {
+	case m := <-transport.out:
+		t.Fatalf("wrote %v before PWR01 was echoed", m)
+	case <-time.After(20 * time.Millisecond):
+	}
+	transport.in <- &Message{"PWR", "01"}
+	echoed := time.Now()
+	transport.expectWritten(t, "SLI03")
+	if elapsed := time.Since(echoed); elapsed < settle-5*time.Millisecond {
+		t.Errorf("wrote SLI03 %v after PWR01 was echoed, expected at least %v", elapsed, settle)
+	}
+	transport.expectWritten(t, "MVL28")
+}
+
+func TestPowerOnSettleAlreadyOn(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0), PowerOnSettle(time.Minute))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	changes := make(chan Change, 1)
+	device.NotifyChanges(changes)
+	transport.in <- &Message{"PWR", "01"}
+	<-changes
+
+	// The device is already on, so SLI03 isn't held.
+	client := device.NewSendOnlyClient()
+	for _, m := range []string{"PWR01", "SLI03"} {
+		if err := client.Send(&Message{m[:3], m[3:]}); err != nil {
+			t.Fatal("Send failed:", err)
+		}
+		transport.expectWritten(t, m)
+	}
+}
+
+func TestPowerOnProbe(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0), PowerOnProbe("SLI"))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"PWR", "01"}); err != nil {
+		t.Fatal("Send failed:", err)
+	}
+	transport.expectWritten(t, "PWR01")
+	go client.Send(&Message{"MVL", "28"})
+	waitQueueDepth(t, device, 1)
+	transport.in <- &Message{"PWR", "01"}
+	transport.expectWritten(t, "SLIQSTN")
+	select {
+	case m := <-transport.out:
+		t.Fatalf("wrote %v before the probe was answered", m)
+	case <-time.After(20 * time.Millisecond):
+	}
+	transport.in <- &Message{"SLI", "03"}
+	transport.expectWritten(t, "MVL28")
+}
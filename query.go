@@ -0,0 +1,120 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// waiters tracks goroutines waiting for the Integra device to send a
+// message with a particular command, e.g., the reply to a QSTN query.
+type waiters struct {
+	sync.Mutex
+	m map[string]map[chan *Message]bool
+}
+
+// await registers interest in the next message received with the
+// given command. The message is delivered on the returned channel.
+// The returned function must be called to unregister.
+func (d *Device) await(command string) (<-chan *Message, func()) {
+	c := make(chan *Message, 1)
+	d.waiters.Lock()
+	if d.waiters.m[command] == nil {
+		d.waiters.m[command] = make(map[chan *Message]bool)
+	}
+	d.waiters.m[command][c] = true
+	d.waiters.Unlock()
+	return c, func() {
+		d.waiters.Lock()
+		delete(d.waiters.m[command], c)
+		if len(d.waiters.m[command]) == 0 {
+			delete(d.waiters.m, command)
+		}
+		d.waiters.Unlock()
+	}
+}
+
+// resolve delivers m to everyone waiting for its command. Every
+// waiter gets the same reply, so concurrent queries for a command are
+// all answered by a single reply from the device.
+func (d *Device) resolve(m *Message) {
+	d.waiters.Lock()
+	defer d.waiters.Unlock()
+	for c := range d.waiters.m[m.Command] {
+		c <- m
+	}
+	delete(d.waiters.m, m.Command)
+}
+
+// errNoReply is returned by roundTrip when the device doesn't reply
+// within the reply timeout.
+var errNoReply = errors.New("no reply")
+
+// roundTrip sends the message in request to the Integra device and
+// returns the next message the device sends with the same command,
+// normally the reply. The wait for the reply starts once the message
+// has been written and lasts at most replyTimeout, unless it is zero;
+// the request's context bounds the whole round trip.
+func (d *Device) roundTrip(request *sendRequest, replyTimeout time.Duration) (*Message, error) {
+	ctx := request.ctx
+	// Register before sending so that a prompt reply isn't missed.
+	reply, cancel := d.await(request.message.Command)
+	defer cancel()
+	if err := d.enqueue(request); err != nil {
+		return nil, err
+	}
+	var timeout <-chan time.Time
+	if replyTimeout > 0 {
+		timeout = time.After(replyTimeout)
+	}
+	select {
+	case m := <-reply:
+		return m, nil
+	case <-timeout:
+		return nil, errNoReply
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-d.done:
+		return nil, ErrClosed
+	}
+}
+
+// Query sends a QSTN message for command (e.g., "MVL") to the Integra
+// device, waits for the device's reply and returns the reply's
+// parameter (e.g., "2A"). If the device replies "N/A", Query returns
+// an error wrapping ErrNotAvailable.
+//
+// Query waits until ctx is done, so callers should use a context with
+// a deadline.
+func (c *Client) Query(ctx context.Context, command string) (string, error) {
+	m := &Message{command, "QSTN"}
+	if !c.device.skipValidation {
+		if err := ValidateMessage(m); err != nil {
+			return "", err
+		}
+	}
+	reply, err := c.device.roundTrip(newSendRequest(ctx, m, priorityOf(m)), 0)
+	if err != nil {
+		return "", err
+	}
+	if reply.NotAvailable() {
+		return "", fmt.Errorf("%v: %w", command, ErrNotAvailable)
+	}
+	return reply.Parameter, nil
+}
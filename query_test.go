@@ -0,0 +1,103 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	transport := newFakeTransport()
+	state := map[string]string{"PWR": "01", "MVL": "2A", "AMT": "00", "SLI": "03"}
+	go transport.serve(state)
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for command, expected := range state {
+			wg.Add(1)
+			go func(command, expected string) {
+				defer wg.Done()
+				client := device.NewSendOnlyClient()
+				result, err := client.Query(ctx, command)
+				if err != nil {
+					t.Errorf("Query(%v) failed: %v", command, err)
+				} else if result != expected {
+					t.Errorf("Query(%v) returned %v, expected %v", command, result, expected)
+				}
+			}(command, expected)
+		}
+	}
+	wg.Wait()
+
+	client := device.NewSendOnlyClient()
+	if _, err := client.Query(ctx, "TUN"); !errors.Is(err, ErrNotAvailable) {
+		t.Errorf("Query(TUN) returned %v, expected %v", err, ErrNotAvailable)
+	}
+}
+
+func TestNotAvailable(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewClient()
+	messages := collect(client)
+
+	transport.in <- &Message{"TUN", "08750"}
+	expectReceived(t, messages, "TUN08750")
+	if state := client.State(); state["TUN"] != "08750" {
+		t.Errorf("state %v did not contain TUN:08750", state)
+	}
+	// N/A replies are broadcast but remove the command from the
+	// state.
+	transport.in <- &Message{"TUN", "N/A"}
+	expectReceived(t, messages, "TUNN/A")
+	if state := client.State(); len(state) != 0 {
+		t.Errorf("state %v, expected empty state", state)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	device, err := ConnectTransport(fakeDialer(newFakeTransport()))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client := device.NewSendOnlyClient()
+	if _, err := client.Query(ctx, "MVL"); err != context.DeadlineExceeded {
+		t.Errorf("Query returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	device.waiters.Lock()
+	defer device.waiters.Unlock()
+	if n := len(device.waiters.m); n != 0 {
+		t.Errorf("%v commands still awaited after Query returned", n)
+	}
+}
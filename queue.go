@@ -0,0 +1,183 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// An OverflowPolicy determines what happens when a message arrives
+// for a client whose buffer is full.
+type OverflowPolicy int
+
+const (
+	// Disconnect removes the client from the Device, after which
+	// Receive returns ErrClosed. This is the default.
+	Disconnect OverflowPolicy = iota
+	// DropOldest discards the oldest buffered message to make room
+	// for the new one.
+	DropOldest
+	// DropNewest discards the new message.
+	DropNewest
+	// Coalesce discards a buffered message with the same command
+	// as the new one, so that the client still receives the latest
+	// value of each command. If no buffered message has the same
+	// command, the oldest buffered message is discarded.
+	Coalesce
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Disconnect:
+		return "Disconnect"
+	case DropOldest:
+		return "DropOldest"
+	case DropNewest:
+		return "DropNewest"
+	case Coalesce:
+		return "Coalesce"
+	}
+	return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+}
+
+// queue buffers received messages for a client.
+type queue struct {
+	sync.Mutex
+	messages []*Message
+	size     int
+	policy   OverflowPolicy
+	closed   bool
+	dropped  uint64
+	// ready is signaled when messages are pushed and closed when
+	// the queue is closed.
+	ready chan struct{}
+}
+
+func newQueue(size int, policy OverflowPolicy) *queue {
+	return &queue{
+		messages: make([]*Message, 0, size),
+		size:     size,
+		policy:   policy,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// push adds m to the queue, applying the overflow policy if the queue
+// is full. It returns false if the client must be disconnected.
+func (q *queue) push(m *Message) bool {
+	q.Lock()
+	defer q.Unlock()
+	if q.closed {
+		return true
+	}
+	if len(q.messages) == q.size {
+		switch q.policy {
+		case Disconnect:
+			q.dropped++
+			return false
+		case DropNewest:
+			q.dropped++
+			return true
+		case Coalesce:
+			q.remove(q.coalesced(m))
+		default:
+			q.remove(0)
+		}
+	}
+	q.messages = append(q.messages, m)
+	q.signal()
+	return true
+}
+
+// coalesced returns the index of the buffered message to discard for
+// m under the Coalesce policy: the one with the same command, or else
+// the oldest.
+func (q *queue) coalesced(m *Message) int {
+	for i, queued := range q.messages {
+		if queued.Command == m.Command {
+			return i
+		}
+	}
+	return 0
+}
+
+// remove discards the buffered message at index i.
+func (q *queue) remove(i int) {
+	copy(q.messages[i:], q.messages[i+1:])
+	q.messages = q.messages[:len(q.messages)-1]
+	q.dropped++
+}
+
+// signal wakes up a receiver without blocking.
+func (q *queue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes and returns the oldest message, blocking until one is
+// available. ErrClosed is returned once the queue has been closed and
+// drained.
+func (q *queue) pop(ctx context.Context) (*Message, error) {
+	for {
+		q.Lock()
+		if len(q.messages) > 0 {
+			m := q.messages[0]
+			copy(q.messages, q.messages[1:])
+			q.messages[len(q.messages)-1] = nil
+			q.messages = q.messages[:len(q.messages)-1]
+			if len(q.messages) > 0 && !q.closed {
+				// Let any other receiver take the next one.
+				q.signal()
+			}
+			q.Unlock()
+			return m, nil
+		}
+		if q.closed {
+			q.Unlock()
+			return nil, ErrClosed
+		}
+		q.Unlock()
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// close closes the queue. Buffered messages can still be popped.
+func (q *queue) close() {
+	q.Lock()
+	defer q.Unlock()
+	if !q.closed {
+		q.closed = true
+		close(q.ready)
+	}
+}
+
+// Dropped returns the number of messages dropped, replaced or
+// discarded for the client because its buffer was full.
+func (c *Client) Dropped() uint64 {
+	if c.queue == nil {
+		return 0
+	}
+	c.queue.Lock()
+	defer c.queue.Unlock()
+	return c.queue.dropped
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueueOverflow(t *testing.T) {
+	tests := []struct {
+		policy   OverflowPolicy
+		pushed   string
+		expected string
+		dropped  uint64
+		ok       bool
+	}{
+		{Disconnect, "MVL01 MVL02 MVL03", "MVL01 MVL02", 1, false},
+		{DropOldest, "MVL01 MVL02 AMT01", "MVL02 AMT01", 1, true},
+		{DropNewest, "MVL01 MVL02 AMT01", "MVL01 MVL02", 1, true},
+		{Coalesce, "MVL01 AMT01 MVL02 MVL03", "AMT01 MVL03", 2, true},
+		{Coalesce, "MVL01 AMT01 PWR01", "AMT01 PWR01", 1, true},
+		// Coalesce only applies when the buffer is full.
+		{Coalesce, "MVL01 MVL02", "MVL01 MVL02", 0, true},
+		{DropOldest, "MVL01 MVL02", "MVL01 MVL02", 0, true},
+	}
+	for _, tt := range tests {
+		q := newQueue(2, tt.policy)
+		ok := true
+		for _, m := range strings.Fields(tt.pushed) {
+			ok = q.push(&Message{m[:3], m[3:]}) && ok
+		}
+		if ok != tt.ok {
+			t.Errorf("%v: push of %v returned %v, expected %v", tt.policy, tt.pushed, ok, tt.ok)
+		}
+		q.close()
+		var popped []string
+		for {
+			m, err := q.pop(context.Background())
+			if err != nil {
+				break
+			}
+			popped = append(popped, m.String())
+		}
+		if strings.Join(popped, " ") != tt.expected {
+			t.Errorf("%v: popped %v after pushing %v, expected %v", tt.policy, popped, tt.pushed, tt.expected)
+		}
+		if q.dropped != tt.dropped {
+			t.Errorf("%v: dropped %v, expected %v", tt.policy, q.dropped, tt.dropped)
+		}
+	}
+}
+
+func TestQueuePopContext(t *testing.T) {
+	q := newQueue(1, DropOldest)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.pop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("pop returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+	go q.push(&Message{"PWR", "01"})
+	if m, err := q.pop(context.Background()); err != nil || m.String() != "PWR01" {
+		t.Errorf("pop returned %v, %v, expected PWR01", m, err)
+	}
+}
+
+func TestClientDropped(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.Subscribe(CommandFilter(CmdMasterVolume), BufferSize(1), Overflow(Coalesce))
+	all := collect(device.NewClient())
+	for _, m := range []string{"MVL20", "MVL21", "MVL22", "PWR01"} {
+		transport.in <- &Message{m[:3], m[3:]}
+		expectReceived(t, all, m)
+	}
+	if m, err := client.Receive(); err != nil || m.String() != "MVL22" {
+		t.Errorf("Receive returned %v, %v, expected MVL22", m, err)
+	}
+	if dropped := client.Dropped(); dropped != 2 {
+		t.Errorf("Dropped returned %v, expected 2", dropped)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// readerBufferSize is large enough to hold all but the largest
+// packets (e.g., NRI XML) in the buffer, which allows a bad packet to
+// be skipped without consuming any packets that follow it.
+const readerBufferSize = 64 << 10
+
+// A framingError reports malformed data in an eISCP stream. The
+// packetReader has already skipped past the bad data by the time it
+// returns a framingError, so reading may continue.
+type framingError struct {
+	err error
+}
+
+func (e *framingError) Error() string {
+	return "framing error: " + e.err.Error()
+}
+
+// packetReader reads eISCP packets from a stream such as a TCP
+// connection. A single read from the stream may contain a partial
+// packet or several packets; packetReader reassembles them and
+// resynchronizes on the "ISCP" magic after malformed data.
+type packetReader struct {
+	r           *bufio.Reader
+	endOfPacket byte
+}
+
+// newPacketReader returns a packetReader that reads from r and
+// expects packets to end with the given end-of-packet marker.
+func newPacketReader(r io.Reader, endOfPacket byte) *packetReader {
+	return &packetReader{bufio.NewReaderSize(r, readerBufferSize), endOfPacket}
+}
+
+// readPacket returns the next packet from the stream. A *framingError
+// is returned if garbage had to be skipped or a packet failed its
+// integrity check; any other error comes from the underlying reader.
+func (r *packetReader) readPacket() (eISCPPacket, error) {
+	if skipped, err := r.sync(); err != nil {
+		return nil, err
+	} else if skipped > 0 {
+		return nil, &framingError{fmt.Errorf("skipped %v bytes before packet", skipped)}
+	}
+
+	header, err := r.r.Peek(headerSize)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if err := checkHeader(header); err != nil {
+		_, _ = r.r.Discard(len(iscpMagic))
+		return nil, &framingError{err}
+	}
+
+	size := headerSize + dataSize(header)
+	if size <= r.r.Size() {
+		// Inspect the packet in place so that only the magic
+		// needs to be skipped if it turns out to be bad.
+		buffered, err := r.r.Peek(size)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if err := eISCPPacket(buffered).check(r.endOfPacket); err != nil {
+			_, _ = r.r.Discard(len(iscpMagic))
+			return nil, &framingError{err}
+		}
+		packet := make(eISCPPacket, size)
+		copy(packet, buffered)
+		_, _ = r.r.Discard(size)
+		return packet, nil
+	}
+
+	packet := make(eISCPPacket, size)
+	if _, err := io.ReadFull(r.r, packet); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if err := packet.check(r.endOfPacket); err != nil {
+		return nil, &framingError{err}
+	}
+	return packet, nil
+}
+
+// sync discards bytes until the stream is positioned at the "ISCP"
+// magic and returns the number of bytes discarded.
+func (r *packetReader) sync() (int, error) {
+	skipped := 0
+	for {
+		buf, err := r.r.Peek(len(iscpMagic))
+		if err != nil {
+			return skipped, err
+		}
+		if bytes.Equal(buf, iscpMagic) {
+			return skipped, nil
+		}
+		// Skip to the next byte that could start the magic.
+		n := len(buf)
+		if i := bytes.IndexByte(buf[1:], iscpMagic[0]); i >= 0 {
+			n = i + 1
+		}
+		_, _ = r.r.Discard(n)
+		skipped += n
+	}
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF for use when a
+// packet has been partially read.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// rxPacket returns a packet as sent by the Integra device.
+func rxPacket(message string) []byte {
+	p := newEISCPPacket(message)
+	p[len(p)-1] = endOfPacketRx
+	return p
+}
+
+// readAll reads packets from r until an error other than a
+// framingError occurs and returns the messages and the number of
+// framing errors.
+func readAll(t *testing.T, r *packetReader) ([]string, int) {
+	var messages []string
+	framingErrors := 0
+	for {
+		packet, err := r.readPacket()
+		if _, ok := err.(*framingError); ok {
+			framingErrors++
+			continue
+		}
+		if err == io.EOF {
+			return messages, framingErrors
+		}
+		if err != nil {
+			t.Fatal("readPacket failed:", err)
+		}
+		m, err := packet.message()
+		if err != nil {
+			t.Fatal("message failed:", err)
+		}
+		messages = append(messages, m.String())
+	}
+}
+
+func TestPacketReader(t *testing.T) {
+	long := "NTI" + strings.Repeat("x", 300)
+	huge := "NRI" + strings.Repeat("<x/>", readerBufferSize/4)
+	badHeader := rxPacket("PWR01")
+	badHeader[headerSizeIndex+3] = 0x11
+	badEnd := newEISCPPacket("PWR01")
+	// Data segment "!1SLI03\x1a\r\n".
+	crlf := newEISCPPacket("SLI03\x1a\r")
+	tests := []struct {
+		name          string
+		input         [][]byte
+		expected      []string
+		framingErrors int
+	}{
+		{"single", [][]byte{rxPacket("PWR01")}, []string{"PWR01"}, 0},
+		{"concatenated",
+			[][]byte{rxPacket("PWR01"), rxPacket(long), rxPacket("MVL2A")},
+			[]string{"PWR01", long, "MVL2A"}, 0},
+		{"larger than buffer",
+			[][]byte{rxPacket(huge), rxPacket("AMT00")},
+			[]string{huge, "AMT00"}, 0},
+		{"CR LF after end of packet",
+			[][]byte{crlf, rxPacket("AMT01")},
+			[]string{"SLI03", "AMT01"}, 0},
+		{"leading garbage",
+			[][]byte{[]byte("xxIS\x00"), rxPacket("PWR00")},
+			[]string{"PWR00"}, 1},
+		{"bad header",
+			[][]byte{badHeader, rxPacket("PWR00")},
+			[]string{"PWR00"}, 2},
+		{"bad end of packet",
+			[][]byte{badEnd, rxPacket("PWR00")},
+			[]string{"PWR00"}, 2},
+	}
+	for _, test := range tests {
+		input := bytes.Join(test.input, nil)
+		readers := map[string]io.Reader{
+			"whole":    bytes.NewReader(input),
+			"one byte": iotest.OneByteReader(bytes.NewReader(input)),
+			"half":     iotest.HalfReader(bytes.NewReader(input)),
+		}
+		for name, reader := range readers {
+			messages, framingErrors := readAll(t, newPacketReader(reader, endOfPacketRx))
+			if strings.Join(messages, ",") != strings.Join(test.expected, ",") {
+				t.Errorf("%v (%v): got %d messages, expected %d",
+					test.name, name, len(messages), len(test.expected))
+			}
+			if framingErrors != test.framingErrors {
+				t.Errorf("%v (%v): got %v framing errors, expected %v",
+					test.name, name, framingErrors, test.framingErrors)
+			}
+		}
+	}
+}
+
+func TestPacketReaderTruncated(t *testing.T) {
+	input := rxPacket("PWR01")
+	r := newPacketReader(bytes.NewReader(input[:len(input)-2]), endOfPacketRx)
+	if _, err := r.readPacket(); err != io.ErrUnexpectedEOF {
+		t.Errorf("expected %v but got %v", io.ErrUnexpectedEOF, err)
+	}
+}
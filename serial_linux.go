@@ -0,0 +1,100 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package integra
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// baudRates maps supported serial line speeds to termios constants.
+// ISCP devices use 9600 baud by default.
+var baudRates = map[int]uint32{
+	2400:   syscall.B2400,
+	4800:   syscall.B4800,
+	9600:   syscall.B9600,
+	19200:  syscall.B19200,
+	38400:  syscall.B38400,
+	57600:  syscall.B57600,
+	115200: syscall.B115200,
+}
+
+// OpenSerial opens the serial port at path (e.g., /dev/ttyUSB0) and
+// returns a Transport that speaks ISCP over it. The port is configured
+// for raw 8N1 communication at the given baud rate.
+func OpenSerial(path string, baud int) (Transport, error) {
+	speed, ok := baudRates[baud]
+	if !ok {
+		return nil, fmt.Errorf("unsupported baud rate %v", baud)
+	}
+	// Opening in non-blocking mode lets the runtime poller manage
+	// the file, so Close unblocks a pending Read.
+	f, err := os.OpenFile(path, os.O_RDWR|syscall.O_NOCTTY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := configureSerial(f, speed); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("configuring %v: %v", path, err)
+	}
+	return newISCPTransport(f, TerminatorCR), nil
+}
+
+// configureSerial puts the terminal f into raw 8N1 mode at the given
+// speed.
+func configureSerial(f *os.File, speed uint32) error {
+	rc, err := f.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var errno syscall.Errno
+	err = rc.Control(func(fd uintptr) {
+		var t syscall.Termios
+		if _, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd,
+			syscall.TCGETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+			return
+		}
+		var speedMask uint32
+		for _, s := range baudRates {
+			speedMask |= s
+		}
+		t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK |
+			syscall.ISTRIP | syscall.INLCR | syscall.IGNCR |
+			syscall.ICRNL | syscall.IXON | syscall.IXOFF
+		t.Oflag &^= syscall.OPOST
+		t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON |
+			syscall.ISIG | syscall.IEXTEN
+		t.Cflag &^= syscall.CSIZE | syscall.PARENB | syscall.CSTOPB | speedMask
+		t.Cflag |= syscall.CS8 | syscall.CREAD | syscall.CLOCAL | speed
+		t.Ispeed = speed
+		t.Ospeed = speed
+		t.Cc[syscall.VMIN] = 1
+		t.Cc[syscall.VTIME] = 0
+		_, _, errno = syscall.Syscall(syscall.SYS_IOCTL, fd,
+			syscall.TCSETS, uintptr(unsafe.Pointer(&t)))
+	})
+	if err != nil {
+		return err
+	}
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
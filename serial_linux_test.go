@@ -0,0 +1,85 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package integra
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+	"unsafe"
+)
+
+// openPTY opens a pseudo-terminal pair and returns the master and the
+// path of the slave, which stands in for a serial port.
+func openPTY(t *testing.T) (*os.File, string) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skip("pseudo-terminals unavailable:", err)
+	}
+	var unlock, n int32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(),
+		syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		t.Fatal("TIOCSPTLCK failed:", errno)
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(),
+		syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		t.Fatal("TIOCGPTN failed:", errno)
+	}
+	return master, fmt.Sprintf("/dev/pts/%d", n)
+}
+
+func TestOpenSerial(t *testing.T) {
+	master, path := openPTY(t)
+	defer master.Close()
+
+	transport, err := OpenSerial(path, 9600)
+	if err != nil {
+		t.Fatal("OpenSerial failed:", err)
+	}
+	defer transport.Close()
+
+	if _, err := master.Write([]byte("!1PWR01\x1a")); err != nil {
+		t.Fatal("Write failed:", err)
+	}
+	m, err := transport.ReadMessage()
+	if err != nil {
+		t.Fatal("ReadMessage failed:", err)
+	}
+	if m.String() != "PWR01" {
+		t.Errorf("%v did not match expected PWR01", m)
+	}
+
+	if err := transport.WriteMessage(&Message{"MVL", "2A"}); err != nil {
+		t.Fatal("WriteMessage failed:", err)
+	}
+	buffer := make([]byte, 64)
+	n, err := master.Read(buffer)
+	if err != nil {
+		t.Fatal("Read failed:", err)
+	}
+	if result := string(buffer[:n]); result != "!1MVL2A\r" {
+		t.Errorf("%q did not match expected %q", result, "!1MVL2A\r")
+	}
+}
+
+func TestOpenSerialBadBaud(t *testing.T) {
+	if _, err := OpenSerial("/dev/null", 1234); err == nil {
+		t.Error("expected non-nil error")
+	}
+}
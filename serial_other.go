@@ -0,0 +1,30 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+// +build !linux
+
+package integra
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// OpenSerial opens the serial port at path and returns a Transport
+// that speaks ISCP over it. Serial ports are currently only supported
+// on Linux.
+func OpenSerial(path string, baud int) (Transport, error) {
+	return nil, fmt.Errorf("serial ports are not supported on %v", runtime.GOOS)
+}
@@ -24,6 +24,11 @@ Server also offers a simple HTTP interface at /integra for sending
 ISCP (Integra Serial Control Protocol) messages and reading the
 current state of the device.
 
+The server connects to the device at the address given by the
+-integraaddr flag. Alternatively, the -discover flag finds the device
+on the local network (use -discovermatch to select a device by model or
+MAC when there are several).
+
 The following examples assume this server is running on localhost port
 8080.
 
@@ -42,17 +47,63 @@ bash to replace occurrences of \n with newlines.) Example:
   $ curl :8080/integra -d $'PWR01\nMVLUP\nSLI03'
   ok
 
+Since the device ignores most messages while powering on, messages
+following a power on message are held until the device echoes it and
+the -poweronsettle delay passes (and, if -poweronprobe is given, the
+device answers a query for that command).
+
+Messages are checked against the integra package's command catalog
+before any are sent; a request containing an unknown parameter for a
+known command is rejected with 400 Bad Request:
+
+  $ curl :8080/integra -d MVL999
+  MVL999: invalid parameter "999" for Master Volume
+
+The -skipvalidation flag disables the check, e.g., to send commands the
+catalog doesn't cover for a particular model.
+
+With the -coalesce flag, a waiting message setting a value, such as
+MVL20, is replaced by a later one setting the same command. Adding
+-stepinterval also limits step messages for the same command, such as
+MVLUP, to one per interval; a request containing one that would be sent
+too soon after another is rejected with 429 Too Many Requests before
+any of its messages are sent:
+
+  $ curl :8080/integra -d $'MVLUP\nMVLUP'
+  MVLUP: rate limited
+
+To wait until the device reports each value sent, resending messages
+it ignores (e.g., while powering on), add a confirm query parameter.
+The response is 504 Gateway Timeout if a message isn't confirmed, or
+409 Conflict if -coalesce replaced it with a later request's value:
+
+  $ curl ':8080/integra?confirm=1' -d $'PWR01\nSLI03'
+  ok
+
 Example command to query the Integra device state by issuing a GET
 request to /integra (returns JSON):
 
   $ curl :8080/integra
   {"MVL":"42","PWR":"01"}
 
-Note that the device state reported by GET /integra is not necessarily
-complete; it is made up of the messages received from the Integra
-device since the server was started. If desired values are missing
-from the reported device state, it can be useful to send a series of
-QSTN messages to populate the state:
+To query the Integra device for the current value of a single command,
+add a query parameter. The response is 404 Not Found if the device
+replies N/A (i.e., the command is unsupported or currently
+unavailable):
+
+  $ curl :8080/integra?query=MVL
+  {"MVL":"42"}
+  $ curl :8080/integra?query=TUN
+  TUN: not available
+
+On startup, the server queries the Integra device for every command in
+the integra package's catalog that can be queried, and GET requests
+wait for the replies, so the reported device state is complete from
+the start (commands the device doesn't support are left out). With
+-populate=false, the state is instead made up of the messages received
+from the Integra device since the server was started. If desired
+values are missing from the reported device state, it can be useful to
+send a series of QSTN messages to populate the state:
 
   $ curl :8080/integra
   {}
@@ -61,12 +112,24 @@ QSTN messages to populate the state:
   $ curl :8080/integra
   {"MVL":"42","PWR":"01","SLI":"03"}
 
+To address zone 2, 3 or 4 of a multi-zone receiver, add a zone query
+parameter. Main zone commands in POST requests and queries are mapped
+to the zone's commands (e.g., MVL to ZVL in zone 2), and GET requests
+report only the zone's state:
+
+  $ curl ':8080/integra?zone=2' -d $'PWR01\nMVL20'
+  ok
+  $ curl ':8080/integra?zone=2'
+  {"ZPW":"01","ZVL":"20"}
+
 */
 package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
@@ -74,6 +137,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -82,11 +146,61 @@ import (
 )
 
 var (
-	httpaddr    = flag.String("httpaddr", ":8080", "HTTP listen address")
-	integraaddr = flag.String("integraaddr", ":60128", "Integra device address")
-	verbose     = flag.Bool("verbose", false, "Verbose logging")
+	httpaddr      = flag.String("httpaddr", ":8080", "HTTP listen address")
+	integraaddr   = flag.String("integraaddr", ":60128", "Integra device address")
+	discover      = flag.Bool("discover", false, "Discover Integra device on the LAN instead of using -integraaddr")
+	discovermatch = flag.String("discovermatch", "", "Model or MAC of device to use with -discover (default first found)")
+	serialport    = flag.String("serialport", "", "Integra device serial port (used instead of -integraaddr)")
+	baud          = flag.Int("baud", 9600, "Integra device serial port baud rate")
+	rawiscp       = flag.Bool("rawiscp", false, "Speak raw ISCP instead of eISCP to -integraaddr (e.g., a ser2net bridge)")
+	terminator    = flag.String("terminator", "cr", "ISCP message terminator for -rawiscp: cr, lf, crlf or eof")
+	coalesce      = flag.Bool("coalesce", false, "Coalesce waiting messages that set the same command, e.g., from volume slider drags")
+	stepinterval  = flag.Duration("stepinterval", 0, "With -coalesce, minimum interval between step messages like MVLUP (0 for no limit)")
+	poweronsettle = flag.Duration("poweronsettle", 3*time.Second, "Time to hold other messages after the device echoes a power on message (0 to disable)")
+	poweronprobe  = flag.String("poweronprobe", "", "Command to query after -poweronsettle until the device replies (e.g., SLI)")
+	populate      = flag.Bool("populate", true, "Query the device for the state of every queryable catalog command on startup")
+	poll          = flag.Duration("poll", time.Minute, "Interval at which to poll the device to detect dead connections (0 to disable)")
+	skipvalidate  = flag.Bool("skipvalidation", false, "Send messages without checking them against the command catalog")
+	verbose       = flag.Bool("verbose", false, "Verbose logging")
 )
 
+// discoverDevice returns the address of the first Integra device
+// discovered on the LAN whose model or MAC matches -discovermatch.
+func discoverDevice() (string, error) {
+	devices, err := integra.Discover(context.Background())
+	if err != nil {
+		return "", err
+	}
+	for _, d := range devices {
+		if *discovermatch == "" ||
+			strings.EqualFold(d.Model, *discovermatch) ||
+			strings.EqualFold(d.MAC, strings.Replace(*discovermatch, ":", "", -1)) {
+			log.Printf("Using %v (%v) at %v\n", d.Model, d.MAC, d.Address)
+			return d.Address, nil
+		}
+	}
+	return "", fmt.Errorf("no matching device among %v discovered", len(devices))
+}
+
+// sendTimeout bounds the time spent sending messages to the Integra
+// device on behalf of an HTTP or websocket request, not counting the
+// time they may be held while the device powers on. It is a variable
+// so that tests can shorten it.
+var sendTimeout = 5 * time.Second
+
+// holdTimeout returns the deadline for sending messages that may be
+// held while the device powers on (see -poweronsettle).
+func holdTimeout() time.Duration {
+	return sendTimeout + integra.MaxPowerOnHold
+}
+
+var terminators = map[string]string{
+	"cr":   integra.TerminatorCR,
+	"lf":   integra.TerminatorLF,
+	"crlf": integra.TerminatorCRLF,
+	"eof":  integra.TerminatorEOF,
+}
+
 // websocketRead blocks waiting for messages to arrive from the
 // websocket connection and forwards them to the Integra device.
 func websocketRead(wsConn *websocket.Conn, integraClient *integra.Client) {
@@ -107,7 +221,9 @@ func websocketRead(wsConn *websocket.Conn, integraClient *integra.Client) {
 			log.Println("Unmarshall failed:", err)
 		}
 
-		err = integraClient.Send(&message)
+		ctx, cancel := context.WithTimeout(context.Background(), holdTimeout())
+		err = integraClient.SendContext(ctx, &message)
+		cancel()
 		if err != nil {
 			log.Println("Send failed:", err)
 			continue
@@ -154,7 +270,7 @@ func serveWs(client *integra.Client, w http.ResponseWriter, r *http.Request) {
 	websocketRead(conn, client)
 }
 
-func serveIntegraPost(client *integra.Client, w http.ResponseWriter, r *http.Request) {
+func serveIntegraPost(zone *integra.Zone, w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	b, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -167,16 +283,54 @@ func serveIntegraPost(client *integra.Client, w http.ResponseWriter, r *http.Req
 		http.Error(w, "Max messages (10) exceeded", http.StatusBadRequest)
 		return
 	}
-	for i, messageBytes := range messages {
+	// Check every message before sending any so that a typo in
+	// the last message doesn't leave the device half configured.
+	parsed := make([]*integra.Message, 0, len(messages))
+	for _, messageBytes := range messages {
 		message, err := integra.NewMessage(messageBytes)
+		if err == nil {
+			message, err = zone.Message(message)
+		}
+		if err == nil && !*skipvalidate {
+			err = integra.ValidateMessage(message)
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if i > 0 {
-			time.Sleep(50 * time.Millisecond)
+		parsed = append(parsed, message)
+	}
+	// Likewise check the step rate limit for the whole request so
+	// that it isn't half applied.
+	if err := zone.Client().CheckRateLimit(parsed...); err != nil {
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+	confirm := r.URL.Query().Get("confirm") != ""
+	// Allow for messages following PWR01 being held until the
+	// device is ready so that they aren't dropped.
+	ctx, cancel := context.WithTimeout(r.Context(), holdTimeout())
+	defer cancel()
+	// The Device spaces the messages out so that the receiver
+	// doesn't drop any.
+	for _, message := range parsed {
+		if confirm {
+			err = zone.Client().SendConfirm(ctx, message)
+		} else {
+			err = zone.Client().SendContext(ctx, message)
+		}
+		if err == context.DeadlineExceeded || errors.Is(err, integra.ErrNotConfirmed) {
+			http.Error(w, err.Error(), http.StatusGatewayTimeout)
+			return
+		}
+		if errors.Is(err, integra.ErrRateLimited) {
+			http.Error(w, message.String()+": "+err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		if errors.Is(err, integra.ErrSuperseded) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
 		}
-		err = client.Send(message)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -185,22 +339,87 @@ func serveIntegraPost(client *integra.Client, w http.ResponseWriter, r *http.Req
 	fmt.Fprintln(w, "ok")
 }
 
+// serveIntegraQuery queries the Integra device for the value of the
+// command given by the query parameter and responds with it as JSON.
+func serveIntegraQuery(zone *integra.Zone, w http.ResponseWriter, r *http.Request) {
+	command, err := zone.Command(r.URL.Query().Get("query"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), sendTimeout)
+	defer cancel()
+	parameter, err := zone.Client().Query(ctx, command)
+	switch {
+	case errors.Is(err, integra.ErrNotAvailable):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case err == context.DeadlineExceeded:
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]string{command: parameter})
+}
+
+// writeJSON writes v to w as JSON.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Println("Marshal failed:", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(b)
+	if err != nil {
+		log.Println("Write failed:", err)
+	}
+}
+
+// zones maps values of the zone query parameter to zones.
+var zones = map[string]integra.ZoneID{
+	"main": integra.MainZone,
+	"1":    integra.MainZone,
+	"2":    integra.Zone2,
+	"3":    integra.Zone3,
+	"4":    integra.Zone4,
+}
+
+// stateReady waits until the device state has been populated. It
+// reports false if the request was cancelled first, in which case it
+// has responded with an error.
+func stateReady(device *integra.Device, w http.ResponseWriter, r *http.Request) bool {
+	// Wait at least as long as populating the state can take.
+	ctx, cancel := context.WithTimeout(r.Context(), sendTimeout+device.PopulateTimeout())
+	defer cancel()
+	select {
+	case <-device.StateReady():
+		return true
+	case <-ctx.Done():
+		http.Error(w, "Device state not ready", http.StatusServiceUnavailable)
+		return false
+	}
+}
+
 func serveIntegra(client *integra.Client, w http.ResponseWriter, r *http.Request) {
-	if r.Method == "GET" {
-		state, err := json.Marshal(client.State())
-		if err != nil {
-			log.Println("Marshal failed:", err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		w.Header().Set("Content-Type", "application/json")
-		_, err = w.Write(state)
-		if err != nil {
-			log.Println("Write failed:", err)
-			return
-		}
+	zoneParam := r.URL.Query().Get("zone")
+	id, ok := zones[zoneParam]
+	if zoneParam != "" && !ok {
+		http.Error(w, "Unknown zone "+zoneParam, http.StatusBadRequest)
+		return
+	}
+	zone := client.Zone(id)
+	if r.Method == "GET" && r.URL.Query().Get("query") != "" {
+		serveIntegraQuery(zone, w, r)
+	} else if r.Method == "GET" && zoneParam != "" {
+		writeJSON(w, zone.State())
+	} else if r.Method == "GET" {
+		writeJSON(w, client.State())
 	} else if r.Method == "POST" {
-		serveIntegraPost(client, w, r)
+		serveIntegraPost(zone, w, r)
 	} else {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -250,10 +469,59 @@ func serveRoot() {
 	})
 }
 
+// webappFilter matches the messages the web app displays: power,
+// mute, volume and input of every zone.
+var webappFilter = integra.CommandFilter(
+	integra.CmdPower, integra.CmdMuting, integra.CmdMasterVolume, integra.CmdInputSelector,
+	integra.CmdZone2Power, integra.CmdZone2Muting, integra.CmdZone2Volume, integra.CmdZone2InputSelector,
+	integra.CmdZone3Power, integra.CmdZone3Muting, integra.CmdZone3Volume, integra.CmdZone3InputSelector,
+	integra.CmdZone4Power, integra.CmdZone4Muting, integra.CmdZone4Volume, integra.CmdZone4InputSelector,
+)
+
 func main() {
 	flag.Parse()
 
-	device, err := integra.Connect(*integraaddr)
+	if *discover {
+		address, err := discoverDevice()
+		if err != nil {
+			log.Fatalln("Discovery failed:", err)
+		}
+		*integraaddr = address
+	}
+
+	var device *integra.Device
+	var err error
+	opts := []integra.Option{
+		// Hold the rest of scripts like PWR01\nSLI03 until
+		// the device is ready for them.
+		integra.PowerOnSettle(*poweronsettle),
+		integra.PowerOnProbe(*poweronprobe),
+	}
+	if *coalesce {
+		// Coalesce volume slider drags and repeated volume
+		// up/down presses from webapp clients.
+		opts = append(opts, integra.CoalesceCommands(*stepinterval))
+	}
+	if *skipvalidate {
+		opts = append(opts, integra.SkipValidation())
+	}
+	if *populate {
+		opts = append(opts, integra.PopulateState())
+	}
+	if *poll > 0 {
+		opts = append(opts, integra.Poll(*poll))
+	}
+	if *serialport != "" {
+		device, err = integra.ConnectSerial(*serialport, *baud, opts...)
+	} else if *rawiscp {
+		eol, ok := terminators[*terminator]
+		if !ok {
+			log.Fatalln("Unknown terminator:", *terminator)
+		}
+		device, err = integra.ConnectRawISCP(*integraaddr, eol, opts...)
+	} else {
+		device, err = integra.Connect(*integraaddr, opts...)
+	}
 	if err != nil {
 		log.Fatalln("integra.Connect failed:", err)
 	}
@@ -264,11 +532,14 @@ func main() {
 		http.ServeFile(w, r, "server/webapp.js")
 	})
 	http.HandleFunc("/integra", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && !stateReady(device, w, r) {
+			return
+		}
 		client := device.NewSendOnlyClient()
 		serveIntegra(client, w, r)
 	})
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
-		client := device.NewClient()
+		client := device.Subscribe(webappFilter, integra.BufferSize(64), integra.Overflow(integra.Coalesce))
 		defer client.Close()
 		serveWs(client, w, r)
 	})
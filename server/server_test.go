@@ -0,0 +1,92 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jhesch/integra"
+)
+
+// echoTransport is an integra.Transport for a device that echoes
+// every message written to it.
+type echoTransport struct {
+	mu      sync.Mutex
+	written []string
+	echoes  chan *integra.Message
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newEchoTransport() *echoTransport {
+	return &echoTransport{
+		echoes: make(chan *integra.Message, 100),
+		closed: make(chan struct{}),
+	}
+}
+
+func (t *echoTransport) ReadMessage() (*integra.Message, error) {
+	select {
+	case m := <-t.echoes:
+		return m, nil
+	case <-t.closed:
+		return nil, errors.New("transport closed")
+	}
+}
+
+func (t *echoTransport) WriteMessage(m *integra.Message) error {
+	t.mu.Lock()
+	t.written = append(t.written, m.String())
+	t.mu.Unlock()
+	t.echoes <- m
+	return nil
+}
+
+func (t *echoTransport) Close() error {
+	t.once.Do(func() { close(t.closed) })
+	return nil
+}
+
+func TestPostHeldAfterPowerOn(t *testing.T) {
+	// The device takes longer to settle than sendTimeout.
+	defer func(timeout time.Duration) { sendTimeout = timeout }(sendTimeout)
+	sendTimeout = 20 * time.Millisecond
+	transport := newEchoTransport()
+	dial := func(ctx context.Context) (integra.Transport, error) { return transport, nil }
+	device, err := integra.ConnectTransport(dial, integra.CommandGap(0),
+		integra.PowerOnSettle(100*time.Millisecond))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	r := httptest.NewRequest("POST", "/integra", strings.NewReader("PWR01\nSLI01"))
+	w := httptest.NewRecorder()
+	serveIntegra(device.NewSendOnlyClient(), w, r)
+	if w.Code != 200 || w.Body.String() != "ok\n" {
+		t.Errorf("POST returned %v %q, expected 200 ok", w.Code, w.Body.String())
+	}
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	if written := strings.Join(transport.written, " "); written != "PWR01 SLI01" {
+		t.Errorf("wrote %v, expected PWR01 SLI01", written)
+	}
+}
@@ -15,9 +15,21 @@
 const ON = '01';
 const OFF = '00';
 
+// Commands controlling each zone, keyed by the zone query parameter
+// understood by /integra.
+const ZONES = {
+  'main': {power: 'PWR', mute: 'AMT', volume: 'MVL', input: 'SLI'},
+  '2': {power: 'ZPW', mute: 'ZMT', volume: 'ZVL', input: 'SLZ'},
+  '3': {power: 'PW3', mute: 'MT3', volume: 'VL3', input: 'SL3'},
+  '4': {power: 'PW4', mute: 'MT4', volume: 'VL4', input: 'SL4'},
+};
+
 // Used to avoid sending messages during programmatic UI updates.
 var updatingUI = false;
 
+// The commands of the zone selected in the UI.
+var zone = ZONES['main'];
+
 function enableWidgets(enabled) {
   var state = enabled ? 'enable' : 'disable';
   $('#mute').flipswitch(state);
@@ -25,25 +37,21 @@ function enableWidgets(enabled) {
   $('#input').selectmenu(state)
 }
 
-function initializeState() {
+function initializeState(zoneName) {
   $.ajax({
-    url: '/integra',
+    url: '/integra?zone=' + zoneName,
     dataType: 'json',
 
     success: function(result) {
       updatingUI = true;
-      if ('PWR' in result) {
-        $('#power').prop('checked', (result.PWR == ON)).flipswitch('refresh');
-      }
-      if ('AMT' in result) {
-        $('#mute').prop('checked', (result.AMT == ON)).flipswitch('refresh');
-      }
-      if ('MVL' in result) {
-        var volume = parseInt(result.MVL, 16);
+      $('#power').prop('checked', (result[zone.power] == ON)).flipswitch('refresh');
+      $('#mute').prop('checked', (result[zone.mute] == ON)).flipswitch('refresh');
+      if (zone.volume in result) {
+        var volume = parseInt(result[zone.volume], 16);
         $('#volume').val(volume).slider('refresh');
       }
-      if ('SLI' in result) {
-        $('#input').val(result.SLI).selectmenu('refresh');
+      if (zone.input in result) {
+        $('#input').val(result[zone.input]).selectmenu('refresh');
       }
       // Always enable power, but only enable other widgets if power is on.
       enableWidgets($('#power').prop('checked'));
@@ -72,7 +80,7 @@ $(document).on('pagecreate', '#main', function() {
     return;
   }
 
-  initializeState();
+  initializeState('main');
 
   var conn = new WebSocket('ws://' + document.location.host + '/ws');
 
@@ -85,18 +93,18 @@ $(document).on('pagecreate', '#main', function() {
     updatingUI = true;
 
     switch(message.Command) {
-    case 'PWR':
+    case zone.power:
       $('#power').prop('checked', (message.Parameter == ON)).flipswitch('refresh');
       enableWidgets(message.Parameter == ON);
       break;
-    case 'MVL':
+    case zone.volume:
       var volume = parseInt(message.Parameter, 16);
       $('#volume').val(volume).slider('refresh');
       break;
-    case 'AMT':
+    case zone.mute:
       $('#mute').prop('checked', (message.Parameter == ON)).flipswitch('refresh');
       break;
-    case 'SLI':
+    case zone.input:
       $('#input_' + message.Parameter).prop('selected', true);
       $('#input').selectmenu('refresh');
       break;
@@ -111,24 +119,29 @@ $(document).on('pagecreate', '#main', function() {
     }));
   }
 
+  $('#zone').on('change', function(event) {
+    zone = ZONES[this.value];
+    initializeState(this.value);
+  });
+
   $('#power').on('change', function(event) {
     if (updatingUI) return;
-    sendMessage('PWR', this.checked ? ON : OFF);
+    sendMessage(zone.power, this.checked ? ON : OFF);
   });
 
   $('#mute').on('change', function(event) {
     if (updatingUI) return;
-    sendMessage('AMT', this.checked ? ON : OFF);
+    sendMessage(zone.mute, this.checked ? ON : OFF);
   });
 
   $('#volume').on('slidestop', function(event) {
     if (updatingUI) return;
-    sendMessage('MVL', dec2hex(this.value));
+    sendMessage(zone.volume, dec2hex(this.value));
   });
 
   $('#input').on('change', function(event) {
     if (updatingUI) return;
-    sendMessage('SLI', this.value);
+    sendMessage(zone.input, this.value);
   });
 
 });
@@ -0,0 +1,166 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"fmt"
+	"time"
+)
+
+// queryReplyWindow is how long after a QSTN message is written a
+// message with the same command may be taken to be the reply.
+const queryReplyWindow = 5 * time.Second
+
+// A Source tells how a value in the state was received from the
+// Integra device.
+//
+// ISCP replies aren't marked as such, so the source is a guess: each
+// QSTN message written is matched with the first message with the
+// same command received within 5 seconds, which is taken to be its
+// reply. A message the device sends on its own between a query and
+// its reply is thus reported as the reply, and the reply as a push.
+type Source int
+
+const (
+	// SourcePush means the device sent the message on its own,
+	// e.g., because the volume knob was turned or to echo a
+	// message sent to it.
+	SourcePush Source = iota
+	// SourceQuery means the message was the reply to a QSTN
+	// message.
+	SourceQuery
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourcePush:
+		return "push"
+	case SourceQuery:
+		return "query"
+	}
+	return fmt.Sprintf("Source(%d)", int(s))
+}
+
+// A StateEntry is the known value of a command in the state of the
+// Integra device.
+type StateEntry struct {
+	// Command is the ISCP command, e.g., "MVL".
+	Command string
+	// Parameter is the last parameter received, or "" if the
+	// device replied N/A and the value is no longer known.
+	Parameter string
+	// Time is when the device last reported the value, even if it
+	// was unchanged.
+	Time time.Time
+	// Seq is the state sequence number assigned when the value
+	// last changed. Sequence numbers increase with every change.
+	Seq uint64
+	// Source tells how the value was last reported.
+	Source Source
+}
+
+// A Snapshot is a consistent view of the state of the Integra device.
+type Snapshot struct {
+	// Seq is the sequence number of the latest change reflected
+	// in the snapshot. Pass it to ChangesSince to catch up later.
+	Seq uint64
+	// Entries maps ISCP commands to their state entries.
+	Entries map[string]StateEntry
+}
+
+// Snapshot returns the known state of the Integra device along with
+// its sequence number. Unlike State, it includes the time, sequence
+// number and source of each value. Commands for which the device last
+// replied "N/A" are omitted.
+func (c *Client) Snapshot() Snapshot {
+	return c.device.state.since(0, false)
+}
+
+// ChangesSince returns the state entries that changed after the
+// change with sequence number seq, e.g., the Seq of a Snapshot taken
+// before a client lost its connection. Entries whose Parameter is ""
+// report commands the device has since replied "N/A" to.
+func (c *Client) ChangesSince(seq uint64) Snapshot {
+	return c.device.state.since(seq, true)
+}
+
+// since returns a snapshot of the entries that changed after seq,
+// including unknown entries if requested.
+func (s *state) since(seq uint64, unknown bool) Snapshot {
+	s.RLock()
+	defer s.RUnlock()
+	snapshot := Snapshot{Seq: s.seq, Entries: make(map[string]StateEntry)}
+	for command, entry := range s.m {
+		if entry.Seq > seq && (unknown || entry.Parameter != "") {
+			snapshot.Entries[command] = entry
+		}
+	}
+	return snapshot
+}
+
+// queried records that a QSTN message for command was written at t,
+// discarding expired queries for command.
+func (s *state) queried(command string, t time.Time) {
+	s.Lock()
+	defer s.Unlock()
+	s.queries[command] = append(unexpired(s.queries[command], t), t)
+}
+
+// unqueried forgets the query for command recorded at t, whose QSTN
+// message failed to be written.
+func (s *state) unqueried(command string, t time.Time) {
+	s.Lock()
+	defer s.Unlock()
+	pending := s.queries[command]
+	for i := len(pending) - 1; i >= 0; i-- {
+		if pending[i].Equal(t) {
+			pending = append(pending[:i], pending[i+1:]...)
+			break
+		}
+	}
+	if len(pending) == 0 {
+		delete(s.queries, command)
+	} else {
+		s.queries[command] = pending
+	}
+}
+
+// unexpired returns the queries in pending that are within
+// queryReplyWindow of t.
+func unexpired(pending []time.Time, t time.Time) []time.Time {
+	for len(pending) > 0 && t.Sub(pending[0]) > queryReplyWindow {
+		pending = pending[1:]
+	}
+	return pending
+}
+
+// source returns the source of a message with command received at t,
+// matching it with the oldest pending query for command, if any. Each
+// query is matched at most once; queries older than queryReplyWindow
+// are discarded unmatched. It must be called with s locked.
+func (s *state) source(command string, t time.Time) Source {
+	pending := unexpired(s.queries[command], t)
+	source := SourcePush
+	if len(pending) > 0 {
+		source = SourceQuery
+		pending = pending[1:]
+	}
+	if len(pending) == 0 {
+		delete(s.queries, command)
+	} else {
+		s.queries[command] = pending
+	}
+	return source
+}
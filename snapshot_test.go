@@ -0,0 +1,162 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport), CommandGap(0))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewClient()
+	messages := collect(client)
+
+	transport.in <- &Message{"PWR", "01"}
+	expectReceived(t, messages, "PWR01")
+	transport.in <- &Message{"MVL", "20"}
+	expectReceived(t, messages, "MVL20")
+	before := client.Snapshot()
+	if before.Seq != 2 || len(before.Entries) != 2 {
+		t.Fatalf("Snapshot returned %+v, expected 2 entries at seq 2", before)
+	}
+	if entry := before.Entries["MVL"]; entry.Parameter != "20" || entry.Seq != 2 || entry.Source != SourcePush {
+		t.Errorf("MVL entry %+v, expected 20 pushed at seq 2", entry)
+	}
+
+	// The reply to a query is attributed to it.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go func() {
+		transport.expectWritten(t, "MVLQSTN")
+		transport.in <- &Message{"MVL", "2A"}
+	}()
+	if _, err := client.Query(ctx, "MVL"); err != nil {
+		t.Fatal("Query failed:", err)
+	}
+	expectReceived(t, messages, "MVL2A")
+	// Unchanged values don't get a new sequence number, and N/A
+	// replies are reported as changes.
+	transport.in <- &Message{"PWR", "01"}
+	expectReceived(t, messages, "PWR01")
+	transport.in <- &Message{"TUN", "N/A"}
+	expectReceived(t, messages, "TUNN/A")
+	transport.in <- &Message{"PWR", "N/A"}
+	expectReceived(t, messages, "PWRN/A")
+
+	changes := client.ChangesSince(before.Seq)
+	if changes.Seq != 4 || len(changes.Entries) != 2 {
+		t.Fatalf("ChangesSince(%v) returned %+v, expected 2 entries at seq 4", before.Seq, changes)
+	}
+	if entry := changes.Entries["MVL"]; entry.Parameter != "2A" || entry.Seq != 3 || entry.Source != SourceQuery {
+		t.Errorf("MVL entry %+v, expected 2A from query at seq 3", entry)
+	}
+	if entry := changes.Entries["PWR"]; entry.Parameter != "" || entry.Seq != 4 {
+		t.Errorf("PWR entry %+v, expected unknown at seq 4", entry)
+	}
+	if after := client.Snapshot(); len(after.Entries) != 1 || after.Seq != 4 {
+		t.Errorf("Snapshot returned %+v, expected 1 entry at seq 4", after)
+	}
+	if changes := client.ChangesSince(4); len(changes.Entries) != 0 {
+		t.Errorf("ChangesSince(4) returned %+v, expected no entries", changes)
+	}
+}
+
+func TestSource(t *testing.T) {
+	s := state{m: make(map[string]StateEntry), queries: make(map[string][]time.Time)}
+	now := time.Now()
+	// Two queries are each matched with one message.
+	s.queried("MVL", now)
+	s.queried("MVL", now)
+	// A push arriving between a query and its reply is taken to
+	// be the reply, and the reply to be a push.
+	s.queried("PWR", now)
+	// Queries without a reply within the window expire.
+	s.queried("AMT", now.Add(-2*queryReplyWindow))
+	tests := []struct {
+		command  string
+		expected Source
+	}{
+		{"MVL", SourceQuery},
+		{"MVL", SourceQuery},
+		{"MVL", SourcePush},
+		{"PWR", SourceQuery},
+		{"PWR", SourcePush},
+		{"AMT", SourcePush},
+		{"SLI", SourcePush},
+	}
+	for i, tt := range tests {
+		if source := s.source(tt.command, now); source != tt.expected {
+			t.Errorf("%v: source(%v) returned %v, expected %v", i, tt.command, source, tt.expected)
+		}
+	}
+	if len(s.queries) != 0 {
+		t.Errorf("queries %v still pending, expected none", s.queries)
+	}
+}
+
+func TestQueried(t *testing.T) {
+	s := state{m: make(map[string]StateEntry), queries: make(map[string][]time.Time)}
+	now := time.Now()
+	// Expired queries are discarded even if no message arrives.
+	s.queried("MVL", now.Add(-2*queryReplyWindow))
+	s.queried("MVL", now)
+	if n := len(s.queries["MVL"]); n != 1 {
+		t.Errorf("%v MVL queries pending, expected 1", n)
+	}
+	// A query that failed to be written is forgotten.
+	s.queried("PWR", now)
+	s.unqueried("PWR", now)
+	s.unqueried("MVL", now)
+	if len(s.queries) != 0 {
+		t.Errorf("queries %v still pending, expected none", s.queries)
+	}
+}
+
+// failingTransport is a fakeTransport whose writes fail.
+type failingTransport struct {
+	*fakeTransport
+}
+
+func (t failingTransport) WriteMessage(m *Message) error {
+	return errors.New("write failed")
+}
+
+func TestQueryWriteFailed(t *testing.T) {
+	transport := failingTransport{newFakeTransport()}
+	dial := func(ctx context.Context) (Transport, error) { return transport, nil }
+	device, err := ConnectTransport(dial, CommandGap(0))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.NewSendOnlyClient()
+	if err := client.Send(&Message{"MVL", "QSTN"}); err == nil {
+		t.Error("Send succeeded, expected error")
+	}
+	device.state.RLock()
+	defer device.state.RUnlock()
+	if len(device.state.queries) != 0 {
+		t.Errorf("queries %v still pending, expected none", device.state.queries)
+	}
+}
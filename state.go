@@ -0,0 +1,240 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// referenceVolume is the volume step corresponding to 0 dB on the
+// main zone volume scale used by most Integra devices (-82 dB to
+// +18 dB).
+const referenceVolume = 82
+
+// A Change reports a change to the known state of the Integra device
+// caused by a received message.
+type Change struct {
+	// Command is the ISCP command whose value changed, e.g.,
+	// "MVL".
+	Command string
+	// Old is the previous parameter, or "" if it was unknown.
+	Old string
+	// New is the new parameter, or "" if the device replied N/A
+	// and the value is no longer known.
+	New string
+	// Time is when the message was received.
+	Time time.Time
+	// Message is the received message that caused the change.
+	Message *Message
+	// Seq is the state sequence number assigned to the change.
+	Seq uint64
+	// Source tells whether Message was a reply to a query.
+	Source Source
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%v %q -> %q", c.Command, c.Old, c.New)
+}
+
+// DeviceState is a snapshot of the known state of the Integra device
+// decoded from the messages received from it. Values the device
+// hasn't reported are left as zero values; use Known to tell them
+// apart.
+type DeviceState struct {
+	// Zones holds the state of each zone, indexed by ZoneID.
+	Zones [4]ZoneState
+	// ListeningMode is the main zone's listening mode.
+	ListeningMode ListeningMode
+	// LateNight is the main zone's late night setting.
+	LateNight LateNight
+	// Dimmer is the front panel display's dimmer level.
+	Dimmer Dimmer
+
+	params map[string]string
+}
+
+// ZoneState is the known state of one zone of the Integra device.
+type ZoneState struct {
+	Power bool
+	Muted bool
+	// Volume is the volume in steps, e.g., 0 through 100.
+	Volume int
+	// VolumeDB is the volume in dB relative to the reference
+	// level, assuming the main zone MVL scale on which step 82 is
+	// 0 dB. The other zones' scales vary by model, so VolumeDB is
+	// only set for the main zone.
+	VolumeDB float64
+	Input    Input
+	// Bass and Treble are the tone settings, -10 through +10.
+	Bass, Treble int
+	// TunerFrequency is the tuner frequency as reported by the
+	// device, i.e., in units of 10 kHz for FM (8930 is 89.3 MHz)
+	// and 1 kHz for AM (530 is 530 kHz).
+	TunerFrequency int
+	// Preset is the selected tuner preset.
+	Preset int
+}
+
+// Known reports whether the device has reported a value for the
+// given command, e.g., CmdZone2Volume.
+func (s *DeviceState) Known(command string) bool {
+	_, ok := s.params[command]
+	return ok
+}
+
+// DeviceState returns a snapshot of the known state of the Integra
+// device. See State for the raw parameters.
+func (c *Client) DeviceState() DeviceState {
+	return newDeviceState(c.State())
+}
+
+func newDeviceState(params map[string]string) DeviceState {
+	s := DeviceState{params: params}
+	for id := range s.Zones {
+		z := &s.Zones[id]
+		param := func(command string) string {
+			command, _ = (&Zone{id: ZoneID(id)}).Command(command)
+			return params[command]
+		}
+		power, _ := ParsePower(param(CmdPower))
+		z.Power = power == PowerOn
+		muting, _ := ParseMuting(param(CmdMuting))
+		z.Muted = muting == MutingOn
+		if v, err := masterVolumeRange.Decode(param(CmdMasterVolume)); err == nil {
+			z.Volume = v
+			if ZoneID(id) == MainZone {
+				z.VolumeDB = float64(v - referenceVolume)
+			}
+		}
+		z.Input, _ = ParseInput(param(CmdInputSelector))
+		z.Bass, z.Treble, _ = parseTone(param(CmdFrontTone))
+		z.TunerFrequency, _ = strconv.Atoi(param(CmdTuner))
+		z.Preset, _ = presetRange.Decode(param(CmdPreset))
+	}
+	s.ListeningMode, _ = ParseListeningMode(params[CmdListeningMode])
+	s.LateNight, _ = ParseLateNight(params[CmdLateNight])
+	s.Dimmer, _ = ParseDimmer(params[CmdDimmer])
+	return s
+}
+
+// parseTone parses tone settings such as "B+2T-A", "B00" and "T+4",
+// where A is 10.
+func parseTone(param string) (bass, treble int, err error) {
+	if param == "" {
+		return 0, 0, fmt.Errorf("%w: empty tone", ErrInvalidParameter)
+	}
+	for p := param; p != ""; p = p[3:] {
+		if len(p) < 3 {
+			return 0, 0, fmt.Errorf("%w: malformed tone %q", ErrInvalidParameter, param)
+		}
+		v, err := strconv.ParseInt(p[2:3], 16, 0)
+		if err != nil || v > 10 {
+			return 0, 0, fmt.Errorf("%w: malformed tone %q", ErrInvalidParameter, param)
+		}
+		switch p[1] {
+		case '-':
+			v = -v
+		case '+', '0':
+		default:
+			return 0, 0, fmt.Errorf("%w: malformed tone %q", ErrInvalidParameter, param)
+		}
+		switch p[0] {
+		case 'B':
+			bass = int(v)
+		case 'T':
+			treble = int(v)
+		default:
+			return 0, 0, fmt.Errorf("%w: malformed tone %q", ErrInvalidParameter, param)
+		}
+	}
+	return bass, treble, nil
+}
+
+// NotifyChanges causes changes to the known state of the Integra
+// device to be sent on c. The Device does not block sending to c; the
+// caller must ensure c has sufficient buffer space to keep up. The
+// Device closes c when it is closed, so the caller must not close c.
+func (d *Device) NotifyChanges(c chan<- Change) {
+	d.state.Lock()
+	defer d.state.Unlock()
+	if d.Err() != nil {
+		close(c)
+		return
+	}
+	d.state.notify = append(d.state.notify, c)
+}
+
+// closeNotify closes the channels registered with NotifyChanges. It
+// is called by Close after done is closed.
+func (d *Device) closeNotify() {
+	d.state.Lock()
+	defer d.state.Unlock()
+	for _, c := range d.state.notify {
+		close(c)
+	}
+	d.state.notify = nil
+}
+
+// updateState records the parameter of a received message in the
+// known state and notifies of any change.
+func (d *Device) updateState(m *Message) {
+	now := time.Now()
+	d.state.Lock()
+	defer d.state.Unlock()
+	source := d.state.source(m.Command, now)
+	entry, known := d.state.m[m.Command]
+	old := entry.Parameter
+	var param string
+	if !m.NotAvailable() {
+		param = m.Parameter
+	}
+	if known && old == param {
+		// The value is unchanged but has been confirmed.
+		entry.Time = now
+		entry.Source = source
+		d.state.m[m.Command] = entry
+		return
+	}
+	if !known && param == "" {
+		return
+	}
+	// A value the device replied N/A to is kept with an empty
+	// parameter so that ChangesSince reports it.
+	d.state.seq++
+	d.state.m[m.Command] = StateEntry{
+		Command:   m.Command,
+		Parameter: param,
+		Time:      now,
+		Seq:       d.state.seq,
+		Source:    source,
+	}
+	change := Change{
+		Command: m.Command,
+		Old:     old,
+		New:     param,
+		Time:    now,
+		Message: m,
+		Seq:     d.state.seq,
+		Source:  source,
+	}
+	for _, c := range d.state.notify {
+		select {
+		case c <- change:
+		default:
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTone(t *testing.T) {
+	tests := []struct {
+		param        string
+		bass, treble int
+		ok           bool
+	}{
+		{"B+2T-A", 2, -10, true},
+		{"B00T00", 0, 0, true},
+		{"T+4", 0, 4, true},
+		{"B-1", -1, 0, true},
+		{"", 0, 0, false},
+		{"B+2T", 0, 0, false},
+		{"B+B", 0, 0, false},
+		{"X+2", 0, 0, false},
+		{"B*2", 0, 0, false},
+	}
+	for _, tt := range tests {
+		bass, treble, err := parseTone(tt.param)
+		if tt.ok && (err != nil || bass != tt.bass || treble != tt.treble) {
+			t.Errorf("parseTone(%q) returned %v, %v, %v, expected %v, %v",
+				tt.param, bass, treble, err, tt.bass, tt.treble)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("parseTone(%q) succeeded, expected error", tt.param)
+		}
+	}
+}
+
+func TestDeviceState(t *testing.T) {
+	s := newDeviceState(map[string]string{
+		"PWR": "01",
+		"MVL": "52",
+		"AMT": "00",
+		"SLI": "10",
+		"TFR": "B+2T-4",
+		"TUN": "08930",
+		"PRS": "0A",
+		"LMD": "0C",
+		"ZPW": "01",
+		"ZVL": "1E",
+		"SLZ": "80",
+	})
+	main := s.Zones[MainZone]
+	if !main.Power || main.Muted || main.Volume != 82 || main.VolumeDB != 0 || main.Input != InputDVDBD ||
+		main.Bass != 2 || main.Treble != -4 || main.TunerFrequency != 8930 || main.Preset != 10 {
+		t.Errorf("main zone state is %+v", main)
+	}
+	zone2 := s.Zones[Zone2]
+	if !zone2.Power || zone2.Volume != 30 || zone2.VolumeDB != 0 || zone2.Input != InputSource {
+		t.Errorf("zone 2 state is %+v", zone2)
+	}
+	if s.Zones[Zone3].Power {
+		t.Errorf("zone 3 state is %+v", s.Zones[Zone3])
+	}
+	if s.ListeningMode != ListeningModeAllChStereo {
+		t.Errorf("ListeningMode is %v, expected %v", s.ListeningMode, ListeningModeAllChStereo)
+	}
+	if !s.Known(CmdZone2Volume) || s.Known(CmdZone3Volume) {
+		t.Errorf("Known(ZVL) = %v, Known(VL3) = %v, expected true, false",
+			s.Known(CmdZone2Volume), s.Known(CmdZone3Volume))
+	}
+}
+
+func TestNotifyChanges(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	changes := make(chan Change, 10)
+	device.NotifyChanges(changes)
+
+	for _, m := range []string{"MVL20", "MVL20", "MVL22", "MVLN/A", "MVLN/A", "PWR01"} {
+		transport.in <- &Message{m[:3], m[3:]}
+	}
+	expected := []string{
+		`MVL "" -> "20"`,
+		`MVL "20" -> "22"`,
+		`MVL "22" -> ""`,
+		`PWR "" -> "01"`,
+	}
+	for _, e := range expected {
+		select {
+		case c := <-changes:
+			if c.String() != e {
+				t.Errorf("got change %v, expected %v", c, e)
+			}
+			if c.Message == nil || c.Message.Command != c.Command || c.Time.IsZero() {
+				t.Errorf("change %v has message %v and time %v", c, c.Message, c.Time)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for change %v", e)
+		}
+	}
+	select {
+	case c := <-changes:
+		t.Errorf("got unexpected change %v", c)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Close closes the channel, ending range loops over it.
+	device.Close()
+	if c, ok := <-changes; ok {
+		t.Errorf("got change %v after Close, expected closed channel", c)
+	}
+	late := make(chan Change, 1)
+	device.NotifyChanges(late)
+	if _, ok := <-late; ok {
+		t.Error("NotifyChanges after Close did not close the channel")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+// defaultBufferSize is the number of messages buffered for a client
+// that has not called Receive.
+const defaultBufferSize = 16
+
+// A Filter reports whether a received message should be sent to a
+// client.
+type Filter func(*Message) bool
+
+// CommandFilter returns a Filter matching messages with any of the
+// given commands, e.g., CommandFilter(CmdMasterVolume, CmdMuting).
+func CommandFilter(commands ...string) Filter {
+	set := make(map[string]bool, len(commands))
+	for _, command := range commands {
+		set[command] = true
+	}
+	return func(m *Message) bool {
+		return set[m.Command]
+	}
+}
+
+// ZoneFilter returns a Filter matching messages with commands that
+// belong to the given zone according to the command catalog. System
+// wide commands belong to MainZone.
+func ZoneFilter(id ZoneID) Filter {
+	return func(m *Message) bool {
+		c, ok := LookupCommand(m.Command)
+		return ok && c.Zone == id
+	}
+}
+
+// A SubscribeOption configures a client returned by Subscribe.
+type SubscribeOption func(*subscribeOptions)
+
+type subscribeOptions struct {
+	bufferSize int
+	overflow   OverflowPolicy
+}
+
+// BufferSize sets the number of received messages buffered for the
+// client until it calls Receive. The default is 16; sizes less than 1
+// are treated as 1.
+func BufferSize(n int) SubscribeOption {
+	return func(o *subscribeOptions) {
+		if n < 1 {
+			n = 1
+		}
+		o.bufferSize = n
+	}
+}
+
+// Overflow sets the policy applied when a message arrives for the
+// client while its buffer is full. The default is Disconnect.
+func Overflow(policy OverflowPolicy) SubscribeOption {
+	return func(o *subscribeOptions) {
+		o.overflow = policy
+	}
+}
+
+// Subscribe returns a new Integra device client, ready to send
+// messages and receive the messages matching filter. A nil filter
+// matches all messages. What happens when a matching message arrives
+// while the client's buffer is full is determined by the client's
+// OverflowPolicy; by default the client is removed from the Device,
+// after which Receive returns ErrClosed.
+func (d *Device) Subscribe(filter Filter, opts ...SubscribeOption) *Client {
+	o := subscribeOptions{bufferSize: defaultBufferSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &Client{
+		device: d,
+		queue:  newQueue(o.bufferSize, o.overflow),
+		filter: filter,
+	}
+	select {
+	case d.add <- c:
+	case <-d.done:
+		c.queue.close()
+	}
+	return c
+}
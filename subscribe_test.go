@@ -0,0 +1,78 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribe(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	volume := collect(device.Subscribe(CommandFilter(CmdMasterVolume, CmdMuting)))
+	zone2 := collect(device.Subscribe(ZoneFilter(Zone2)))
+	all := collect(device.NewClient())
+
+	for _, m := range []string{"PWR01", "MVL20", "ZVL10", "AMT01", "SLZ01"} {
+		transport.in <- &Message{m[:3], m[3:]}
+	}
+	expectReceived(t, volume, "MVL20")
+	expectReceived(t, volume, "AMT01")
+	expectReceived(t, zone2, "ZVL10")
+	expectReceived(t, zone2, "SLZ01")
+	for _, m := range []string{"PWR01", "MVL20", "ZVL10", "AMT01", "SLZ01"} {
+		expectReceived(t, all, m)
+	}
+	select {
+	case m := <-volume:
+		t.Errorf("received unexpected %v", m)
+	case m := <-zone2:
+		t.Errorf("received unexpected %v", m)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestSubscribeBufferFull(t *testing.T) {
+	transport := newFakeTransport()
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+
+	client := device.Subscribe(CommandFilter(CmdMasterVolume), BufferSize(2))
+	all := collect(device.NewClient())
+	// PWR01 isn't sent to client; once it has been received, the
+	// broadcast of MVL22 is complete.
+	for _, m := range []string{"MVL20", "MVL21", "MVL22", "PWR01"} {
+		transport.in <- &Message{m[:3], m[3:]}
+		expectReceived(t, all, m)
+	}
+	for _, expected := range []string{"MVL20", "MVL21"} {
+		m, err := client.Receive()
+		if err != nil || m.String() != expected {
+			t.Fatalf("Receive returned %v, %v, expected %v", m, err, expected)
+		}
+	}
+	if m, err := client.Receive(); err != ErrClosed {
+		t.Errorf("Receive returned %v, %v, expected %v", m, err, ErrClosed)
+	}
+}
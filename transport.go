@@ -0,0 +1,96 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"context"
+	"log"
+	"net"
+	"time"
+)
+
+// A Transport carries ISCP messages between a Device and an Integra
+// device. ReadMessage is called from one goroutine while WriteMessage
+// may be called concurrently from another.
+type Transport interface {
+	// ReadMessage blocks until the next message arrives from the
+	// Integra device. Malformed data is logged and skipped; a
+	// non-nil error means the transport is no longer usable.
+	ReadMessage() (*Message, error)
+	// WriteMessage sends a message to the Integra device.
+	WriteMessage(m *Message) error
+	// Close closes the transport. Blocked ReadMessage and
+	// WriteMessage calls return errors.
+	Close() error
+}
+
+// writeDeadliner is implemented by Transports that support write
+// deadlines. The Device sets a deadline before each write.
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// A DialFunc opens a new Transport to an Integra device.
+type DialFunc func(ctx context.Context) (Transport, error)
+
+// eISCPTransport is a Transport that speaks eISCP over a TCP
+// connection.
+type eISCPTransport struct {
+	conn   net.Conn
+	reader *packetReader
+}
+
+// DialEISCP connects to the Integra device at the given TCP address
+// and returns a Transport that speaks eISCP.
+func DialEISCP(ctx context.Context, address string) (Transport, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	return &eISCPTransport{conn, newPacketReader(conn, endOfPacketRx)}, nil
+}
+
+func (t *eISCPTransport) ReadMessage() (*Message, error) {
+	for {
+		packet, err := t.reader.readPacket()
+		if _, ok := err.(*framingError); ok {
+			log.Println("Received bad data:", err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		message, err := packet.message()
+		if err != nil {
+			log.Println("message failed:", err)
+			continue
+		}
+		return message, nil
+	}
+}
+
+func (t *eISCPTransport) WriteMessage(m *Message) error {
+	_, err := t.conn.Write(newEISCPPacket(m.String()))
+	return err
+}
+
+func (t *eISCPTransport) Close() error {
+	return t.conn.Close()
+}
+
+func (t *eISCPTransport) SetWriteDeadline(deadline time.Time) error {
+	return t.conn.SetWriteDeadline(deadline)
+}
@@ -0,0 +1,238 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoZoneCommand is returned when a zone has no equivalent of a
+// main zone command, e.g., zone 4 has no listening mode.
+var ErrNoZoneCommand = errors.New("no equivalent command in zone")
+
+// zoneCommands maps main zone commands to their equivalents in zones
+// 2, 3 and 4, indexed by ZoneID. Empty entries have no equivalent.
+var zoneCommands = map[string][4]string{
+	CmdPower:         {CmdPower, CmdZone2Power, CmdZone3Power, CmdZone4Power},
+	CmdMuting:        {CmdMuting, CmdZone2Muting, CmdZone3Muting, CmdZone4Muting},
+	CmdMasterVolume:  {CmdMasterVolume, CmdZone2Volume, CmdZone3Volume, CmdZone4Volume},
+	CmdInputSelector: {CmdInputSelector, CmdZone2InputSelector, CmdZone3InputSelector, CmdZone4InputSelector},
+	CmdFrontTone:     {CmdFrontTone, CmdZone2Tone, CmdZone3Tone, ""},
+	CmdTuner:         {CmdTuner, CmdZone2Tuner, CmdZone3Tuner, CmdZone4Tuner},
+	CmdPreset:        {CmdPreset, CmdZone2Preset, CmdZone3Preset, CmdZone4Preset},
+	CmdNetOperation:  {CmdNetOperation, CmdZone2NetOperation, CmdZone3NetOperation, CmdZone4NetOperation},
+	CmdListeningMode: {CmdListeningMode, CmdZone2ListeningMode, "", ""},
+	CmdLateNight:     {CmdLateNight, CmdZone2LateNight, "", ""},
+	CmdCinemaFilter:  {CmdCinemaFilter, CmdZone2ReEQ, "", ""},
+}
+
+// A Zone controls one zone of the Integra device, e.g., Zone2, by
+// mapping operations to the zone's commands. Zones are obtained with
+// Client.Zone.
+type Zone struct {
+	client *Client
+	id     ZoneID
+}
+
+// Zone returns the zone with the given ID. The Zone sends messages
+// through c.
+func (c *Client) Zone(id ZoneID) *Zone {
+	return &Zone{c, id}
+}
+
+// ID returns the zone's ID.
+func (z *Zone) ID() ZoneID {
+	return z.id
+}
+
+// Client returns the Client the zone sends messages through.
+func (z *Zone) Client() *Client {
+	return z.client
+}
+
+// Command returns the zone's equivalent of the given main zone
+// command, e.g., "ZVL" for "MVL" in Zone2. In MainZone the command is
+// returned unchanged.
+func (z *Zone) Command(command string) (string, error) {
+	if z.id == MainZone {
+		return command, nil
+	}
+	if z.id < MainZone || z.id > Zone4 {
+		return "", fmt.Errorf("unknown zone %v", z.id)
+	}
+	if equivalent := zoneCommands[command][z.id]; equivalent != "" {
+		return equivalent, nil
+	}
+	return "", fmt.Errorf("%v: %w %v", command, ErrNoZoneCommand, z.id)
+}
+
+// Message returns a copy of the main zone message m with its command
+// replaced by the zone's equivalent.
+func (z *Zone) Message(m *Message) (*Message, error) {
+	command, err := z.Command(m.Command)
+	if err != nil {
+		return nil, err
+	}
+	return &Message{command, m.Parameter}, nil
+}
+
+// State returns the known state of the commands belonging to the
+// zone. See Client.State.
+func (z *Zone) State() map[string]string {
+	state := z.client.State()
+	for command := range state {
+		if c, ok := LookupCommand(command); !ok || c.Zone != z.id {
+			delete(state, command)
+		}
+	}
+	return state
+}
+
+// SetPower turns the zone on or puts it in standby.
+func (z *Zone) SetPower(on bool) error {
+	v := PowerStandby
+	if on {
+		v = PowerOn
+	}
+	return z.send(CmdPower, string(v))
+}
+
+// Power reports whether the zone is on.
+func (z *Zone) Power() (bool, error) {
+	param, err := z.state(CmdPower)
+	if err != nil {
+		return false, err
+	}
+	v, err := ParsePower(param)
+	if err != nil {
+		return false, z.stateError(CmdPower, err)
+	}
+	return v == PowerOn, nil
+}
+
+// SetVolume sets the zone's volume, which must be between 0 and 100.
+func (z *Zone) SetVolume(volume int) error {
+	command, err := z.Command(CmdMasterVolume)
+	if err != nil {
+		return err
+	}
+	c, _ := LookupCommand(command)
+	param, err := c.Range.Encode(volume)
+	if err != nil {
+		return fmt.Errorf("%v: %w", command, err)
+	}
+	return z.client.Send(&Message{command, param})
+}
+
+// Volume returns the zone's volume.
+func (z *Zone) Volume() (int, error) {
+	param, err := z.state(CmdMasterVolume)
+	if err != nil {
+		return 0, err
+	}
+	command, _ := z.Command(CmdMasterVolume)
+	c, _ := LookupCommand(command)
+	v, err := c.Range.Decode(param)
+	if err != nil {
+		return 0, z.stateError(CmdMasterVolume, err)
+	}
+	return v, nil
+}
+
+// VolumeUp raises the zone's volume by one step.
+func (z *Zone) VolumeUp() error {
+	return z.send(CmdMasterVolume, "UP")
+}
+
+// VolumeDown lowers the zone's volume by one step.
+func (z *Zone) VolumeDown() error {
+	return z.send(CmdMasterVolume, "DOWN")
+}
+
+// SetMute mutes or unmutes the zone's audio.
+func (z *Zone) SetMute(mute bool) error {
+	v := MutingOff
+	if mute {
+		v = MutingOn
+	}
+	return z.send(CmdMuting, string(v))
+}
+
+// Muted reports whether the zone's audio is muted.
+func (z *Zone) Muted() (bool, error) {
+	param, err := z.state(CmdMuting)
+	if err != nil {
+		return false, err
+	}
+	v, err := ParseMuting(param)
+	if err != nil {
+		return false, z.stateError(CmdMuting, err)
+	}
+	return v == MutingOn, nil
+}
+
+// SelectInput selects the zone's input source. InputOff and
+// InputSource are only accepted by zones 2, 3 and 4.
+func (z *Zone) SelectInput(input Input) error {
+	return z.send(CmdInputSelector, string(input))
+}
+
+// Input returns the zone's selected input source.
+func (z *Zone) Input() (Input, error) {
+	param, err := z.state(CmdInputSelector)
+	if err != nil {
+		return "", err
+	}
+	v, err := ParseInput(param)
+	if err != nil {
+		return "", z.stateError(CmdInputSelector, err)
+	}
+	return v, nil
+}
+
+// send sends the zone's equivalent of the main zone command with the
+// given parameter.
+func (z *Zone) send(command, param string) error {
+	command, err := z.Command(command)
+	if err != nil {
+		return err
+	}
+	return z.client.Send(&Message{command, param})
+}
+
+// state returns the last parameter received for the zone's
+// equivalent of the main zone command.
+func (z *Zone) state(command string) (string, error) {
+	command, err := z.Command(command)
+	if err != nil {
+		return "", err
+	}
+	z.client.device.state.RLock()
+	entry := z.client.device.state.m[command]
+	z.client.device.state.RUnlock()
+	param := entry.Parameter
+	if param == "" {
+		return "", fmt.Errorf("%v: %w", command, ErrUnknownState)
+	}
+	return param, nil
+}
+
+// stateError wraps an error decoding the state of the zone's
+// equivalent of the main zone command.
+func (z *Zone) stateError(command string, err error) error {
+	command, _ = z.Command(command)
+	return fmt.Errorf("%v: %w", command, err)
+}
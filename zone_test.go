@@ -0,0 +1,108 @@
+// Copyright 2017 Jacob Hesch
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integra
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestZoneCommand(t *testing.T) {
+	tests := []struct {
+		zone     ZoneID
+		command  string
+		expected string
+		err      error
+	}{
+		{MainZone, "MVL", "MVL", nil},
+		{MainZone, "XYZ", "XYZ", nil},
+		{Zone2, "MVL", "ZVL", nil},
+		{Zone2, "SLI", "SLZ", nil},
+		{Zone3, "PWR", "PW3", nil},
+		{Zone4, "AMT", "MT4", nil},
+		{Zone4, "LMD", "", ErrNoZoneCommand},
+		{Zone2, "XYZ", "", ErrNoZoneCommand},
+	}
+	client := (&Device{}).NewSendOnlyClient()
+	for _, tt := range tests {
+		command, err := client.Zone(tt.zone).Command(tt.command)
+		if command != tt.expected || !errors.Is(err, tt.err) {
+			t.Errorf("Zone(%v).Command(%v) returned %q, %v, expected %q, %v",
+				tt.zone, tt.command, command, err, tt.expected, tt.err)
+		}
+	}
+	if _, err := client.Zone(ZoneID(7)).Command("MVL"); err == nil {
+		t.Error("Zone(7).Command(MVL) succeeded, expected error")
+	}
+}
+
+func TestZone(t *testing.T) {
+	transport := newFakeTransport()
+	go transport.serve(map[string]string{"PWR": "01", "MVL": "20"})
+	device, err := ConnectTransport(fakeDialer(transport))
+	if err != nil {
+		t.Fatal("ConnectTransport failed:", err)
+	}
+	defer device.Close()
+	client := device.NewClient()
+	defer client.Close()
+	messages := collect(client)
+	zone := client.Zone(Zone2)
+
+	if err := zone.SetPower(true); err != nil {
+		t.Fatal("SetPower failed:", err)
+	}
+	expectReceived(t, messages, "ZPW01")
+	if err := zone.SetVolume(30); err != nil {
+		t.Fatal("SetVolume failed:", err)
+	}
+	expectReceived(t, messages, "ZVL1E")
+	if err := zone.SelectInput(InputSource); err != nil {
+		t.Fatal("SelectInput failed:", err)
+	}
+	expectReceived(t, messages, "SLZ80")
+	if err := zone.SetMute(true); err != nil {
+		t.Fatal("SetMute failed:", err)
+	}
+	expectReceived(t, messages, "ZMT01")
+
+	if on, err := zone.Power(); err != nil || !on {
+		t.Errorf("Power returned %v, %v, expected true", on, err)
+	}
+	if v, err := zone.Volume(); err != nil || v != 30 {
+		t.Errorf("Volume returned %v, %v, expected 30", v, err)
+	}
+	if input, err := zone.Input(); err != nil || input != InputSource {
+		t.Errorf("Input returned %v, %v, expected %v", input, err, InputSource)
+	}
+	if muted, err := zone.Muted(); err != nil || !muted {
+		t.Errorf("Muted returned %v, %v, expected true", muted, err)
+	}
+	if _, err := client.Zone(Zone3).Volume(); !errors.Is(err, ErrUnknownState) {
+		t.Errorf("Zone3 Volume returned %v, expected %v", err, ErrUnknownState)
+	}
+
+	state := zone.State()
+	expected := map[string]string{"ZPW": "01", "ZVL": "1E", "SLZ": "80", "ZMT": "01"}
+	if len(state) != len(expected) {
+		t.Errorf("State returned %v, expected %v", state, expected)
+	}
+	for command, param := range expected {
+		if state[command] != param {
+			t.Errorf("State returned %v, expected %v", state, expected)
+			break
+		}
+	}
+}